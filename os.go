@@ -4,6 +4,12 @@
 // Package os provides access to operating system related configuration.
 package os
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 var HostOS = hostOS // for monkey patching
 
 type OSType int
@@ -17,6 +23,8 @@ const (
 	GenericLinux
 	OpenSUSE
 	Kubernetes
+	Debian
+	Alpine
 )
 
 func (t OSType) String() string {
@@ -35,10 +43,75 @@ func (t OSType) String() string {
 		return "OpenSUSE"
 	case Kubernetes:
 		return "Kubernetes"
+	case Debian:
+		return "Debian"
+	case Alpine:
+		return "Alpine"
 	}
 	return "Unknown"
 }
 
+// osTypes holds every OS type.
+var osTypes = []OSType{
+	Unknown,
+	Ubuntu,
+	Windows,
+	OSX,
+	CentOS,
+	GenericLinux,
+	OpenSUSE,
+	Kubernetes,
+	Debian,
+	Alpine,
+}
+
+// name returns the canonical name of the OS type, which is its lower case
+// String, such as "ubuntu" or "osx".
+func (t OSType) name() string {
+	return strings.ToLower(t.String())
+}
+
+// parseOSType returns the OS type with the canonical name, ignoring case.
+func parseOSType(name string) (OSType, error) {
+	for _, t := range osTypes {
+		if strings.EqualFold(name, t.name()) {
+			return t, nil
+		}
+	}
+	return Unknown, fmt.Errorf("unknown OS type %q", name)
+}
+
+// MarshalJSON encodes the OS type as its canonical name, such as "ubuntu".
+func (t OSType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.name())
+}
+
+// UnmarshalJSON decodes the OS type from its name, ignoring case. Unknown
+// names decode to Unknown, with an error.
+func (t *OSType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	osType, err := parseOSType(name)
+	*t = osType
+	return err
+}
+
+// MarshalText encodes the OS type as its canonical name, such as "ubuntu",
+// for use in YAML and elsewhere that text is expected.
+func (t OSType) MarshalText() ([]byte, error) {
+	return []byte(t.name()), nil
+}
+
+// UnmarshalText decodes the OS type from its name, ignoring case. Unknown
+// names decode to Unknown, with an error.
+func (t *OSType) UnmarshalText(text []byte) error {
+	osType, err := parseOSType(string(text))
+	*t = osType
+	return err
+}
+
 // EquivalentTo returns true if the OS type is equivalent to another
 // OS type.
 func (t OSType) EquivalentTo(t2 OSType) bool {
@@ -51,8 +124,124 @@ func (t OSType) EquivalentTo(t2 OSType) bool {
 // IsLinux returns true if the OS type is a Linux variant.
 func (t OSType) IsLinux() bool {
 	switch t {
-	case Ubuntu, CentOS, GenericLinux, OpenSUSE:
+	case Ubuntu, CentOS, GenericLinux, OpenSUSE, Debian, Alpine:
 		return true
 	}
 	return false
 }
+
+// OSFamily groups OS types that are derived from a common distribution,
+// and so share packaging and configuration conventions.
+type OSFamily int
+
+const (
+	OtherFamily OSFamily = iota
+	DebianFamily
+	RHELFamily
+	SUSEFamily
+	DarwinFamily
+	WindowsFamily
+)
+
+func (f OSFamily) String() string {
+	switch f {
+	case DebianFamily:
+		return "Debian"
+	case RHELFamily:
+		return "RHEL"
+	case SUSEFamily:
+		return "SUSE"
+	case DarwinFamily:
+		return "Darwin"
+	case WindowsFamily:
+		return "Windows"
+	}
+	return "Other"
+}
+
+// Family returns the family of the OS type, such as RHELFamily for CentOS.
+// OS types that don't belong to one of the families, such as Alpine and
+// Kubernetes, are in the OtherFamily.
+func (t OSType) Family() OSFamily {
+	switch t {
+	case Ubuntu, Debian:
+		return DebianFamily
+	case CentOS:
+		return RHELFamily
+	case OpenSUSE:
+		return SUSEFamily
+	case OSX:
+		return DarwinFamily
+	case Windows:
+		return WindowsFamily
+	}
+	return OtherFamily
+}
+
+// nameAliases maps the lower case aliases accepted by OSTypeForName, in
+// addition to the canonical names, to their OS type.
+var nameAliases = map[string]OSType{
+	"macos":  OSX,
+	"darwin": OSX,
+	"linux":  GenericLinux,
+}
+
+// OSTypeForName returns the OS type with the canonical name, such as
+// "ubuntu" or "centos", or one of the aliases "macos", "darwin" and "linux".
+// The match is case insensitive. Unlike OSTypeForFriendlyName, no other
+// spellings are accepted. Unknown names, including "unknown" itself, return
+// Unknown with an error.
+func OSTypeForName(name string) (OSType, error) {
+	if t, ok := nameAliases[strings.ToLower(name)]; ok {
+		return t, nil
+	}
+	if t, err := parseOSType(name); err == nil && t != Unknown {
+		return t, nil
+	}
+	return Unknown, fmt.Errorf("unknown OS type %q", name)
+}
+
+// friendlyNames maps normalised, user supplied OS names to their OS type.
+var friendlyNames = map[string]OSType{
+	"ubuntu":           Ubuntu,
+	"windows":          Windows,
+	"windowsserver":    Windows,
+	"osx":              OSX,
+	"macos":            OSX,
+	"macosx":           OSX,
+	"darwin":           OSX,
+	"centos":           CentOS,
+	"rhel":             CentOS,
+	"redhat":           CentOS,
+	"redhatenterprise": CentOS,
+	"generic":          GenericLinux,
+	"genericlinux":     GenericLinux,
+	"linux":            GenericLinux,
+	"opensuse":         OpenSUSE,
+	"opensuseleap":     OpenSUSE,
+	"suse":             OpenSUSE,
+	"kubernetes":       Kubernetes,
+	"k8s":              Kubernetes,
+	"debian":           Debian,
+	"alpine":           Alpine,
+}
+
+// OSTypeForFriendlyName returns the OS type for a user supplied OS name,
+// leniently accepting common spellings and aliases such as "Ubuntu Linux",
+// "Cent OS" or "RHEL". The match is case insensitive and ignores spaces,
+// dashes, underscores and any "linux" qualifier.
+func OSTypeForFriendlyName(name string) (OSType, error) {
+	normalised := strings.ToLower(name)
+	for _, sep := range []string{" ", "-", "_", "\t"} {
+		normalised = strings.Replace(normalised, sep, "", -1)
+	}
+	if t, ok := friendlyNames[normalised]; ok {
+		return t, nil
+	}
+	if stripped := strings.Replace(normalised, "linux", "", -1); stripped != "" {
+		if t, ok := friendlyNames[stripped]; ok {
+			return t, nil
+		}
+	}
+	return Unknown, fmt.Errorf("unknown OS name %q", name)
+}
@@ -5,6 +5,7 @@ package os
 
 import (
 	"errors"
+	"io/fs"
 	"io/ioutil"
 	"strings"
 	"sync"
@@ -14,8 +15,11 @@ var (
 	// osReleaseFile is the name of the file that is read in order to determine
 	// the linux type release version.
 	osReleaseFile = "/etc/os-release"
-	osOnce        sync.Once
-	os            OSType // filled in by the first call to hostOS
+	// redhatReleaseFile is read instead on older RHEL family hosts that
+	// have no os-release file.
+	redhatReleaseFile = "/etc/redhat-release"
+	osOnce            sync.Once
+	os                OSType // filled in by the first call to hostOS
 )
 
 func hostOS() OSType {
@@ -31,21 +35,52 @@ func hostOS() OSType {
 
 func updateOS(f string) (OSType, error) {
 	values, err := ReadOSRelease(f)
+	if errors.Is(err, fs.ErrNotExist) {
+		values, err = readRedhatRelease(redhatReleaseFile)
+	}
 	if err != nil {
 		return Unknown, err
 	}
 	switch values["ID"] {
 	case strings.ToLower(Ubuntu.String()):
 		return Ubuntu, nil
-	case strings.ToLower(CentOS.String()):
+	case strings.ToLower(CentOS.String()), "rocky", "almalinux", "amzn", "ol":
+		// Rocky Linux and AlmaLinux are CentOS compatible rebuilds of RHEL,
+		// and Amazon Linux and Oracle Linux are also of the RHEL family.
 		return CentOS, nil
-	case strings.ToLower(OpenSUSE.String()):
+	case strings.ToLower(OpenSUSE.String()), "opensuse-tumbleweed", "sles":
+		// Tumbleweed and SUSE Linux Enterprise Server are of the openSUSE
+		// family.
 		return OpenSUSE, nil
+	case strings.ToLower(Debian.String()):
+		return Debian, nil
+	case strings.ToLower(Alpine.String()):
+		return Alpine, nil
 	default:
 		return GenericLinux, nil
 	}
 }
 
+// readRedhatRelease parses the redhat-release file, such as
+// "CentOS Linux release 7.9.2009 (Core)", into the os-release ID. RHEL is
+// treated as CentOS, which is built from the same sources.
+func readRedhatRelease(f string) (map[string]string, error) {
+	contents, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	release := strings.TrimSpace(string(contents))
+	name := strings.SplitN(release, " release ", 2)
+	if len(name) != 2 || name[0] == "" {
+		return nil, errors.New("unexpected contents in " + f)
+	}
+	id := strings.ToLower(strings.Fields(name[0])[0])
+	if strings.HasPrefix(name[0], "Red Hat Enterprise Linux") {
+		id = strings.ToLower(CentOS.String())
+	}
+	return map[string]string{"ID": id}, nil
+}
+
 // ReadOSRelease parses the information in the os-release file.
 //
 // See http://www.freedesktop.org/software/systemd/man/os-release.html.
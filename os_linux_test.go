@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type linuxSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&linuxSuite{})
+
+func (s *linuxSuite) TestUpdateOSRedhatRelease(c *gc.C) {
+	d := c.MkDir()
+	for i, test := range []struct {
+		contents string
+		expected OSType
+	}{
+		{"CentOS Linux release 7.9.2009 (Core)\n", CentOS},
+		{"Red Hat Enterprise Linux Server release 7.9 (Maipo)\n", CentOS},
+		{"Rocky Linux release 9.2 (Blue Onyx)\n", CentOS},
+		{"AlmaLinux release 9.4 (Seafoam Ocelot)\n", CentOS},
+		{"Fedora release 20 (Heisenbug)\n", GenericLinux},
+	} {
+		c.Logf("%d: %s", i, test.contents)
+		filename := filepath.Join(d, "redhat-release")
+		err := ioutil.WriteFile(filename, []byte(test.contents), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+		s.PatchValue(&redhatReleaseFile, filename)
+
+		osType, err := updateOS(filepath.Join(d, "os-release"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(osType, gc.Equals, test.expected)
+	}
+}
+
+func (s *linuxSuite) TestUpdateOSNoReleaseFiles(c *gc.C) {
+	d := c.MkDir()
+	s.PatchValue(&redhatReleaseFile, filepath.Join(d, "redhat-release"))
+
+	_, err := updateOS(filepath.Join(d, "os-release"))
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
+
+func (s *linuxSuite) TestUpdateOSBadRedhatRelease(c *gc.C) {
+	d := c.MkDir()
+	filename := filepath.Join(d, "redhat-release")
+	err := ioutil.WriteFile(filename, []byte("garbage\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&redhatReleaseFile, filename)
+
+	_, err = updateOS(filepath.Join(d, "os-release"))
+	c.Assert(err, gc.ErrorMatches, "unexpected contents in .*")
+}
+
+func (s *linuxSuite) TestUpdateOSAmazonLinux(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(filename, []byte(`NAME="Amazon Linux"
+VERSION="2023"
+ID="amzn"
+ID_LIKE="fedora"
+VERSION_ID="2023"
+PRETTY_NAME="Amazon Linux 2023.5.20240708"
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	osType, err := updateOS(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, CentOS)
+	c.Check(osType.Family(), gc.Equals, RHELFamily)
+}
+
+func (s *linuxSuite) TestUpdateOSOracleLinux(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(filename, []byte(`NAME="Oracle Linux Server"
+VERSION="9.3"
+ID="ol"
+ID_LIKE="fedora"
+VERSION_ID="9.3"
+PLATFORM_ID="platform:el9"
+PRETTY_NAME="Oracle Linux Server 9.3"
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	osType, err := updateOS(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, CentOS)
+}
+
+func (s *linuxSuite) TestUpdateOSSLES(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(filename, []byte(`NAME="SLES"
+VERSION="15-SP5"
+VERSION_ID="15.5"
+PRETTY_NAME="SUSE Linux Enterprise Server 15 SP5"
+ID="sles"
+ID_LIKE="suse"
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	osType, err := updateOS(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, OpenSUSE)
+}
+
+func (s *linuxSuite) TestUpdateOSTumbleweed(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(filename, []byte(`NAME="openSUSE Tumbleweed"
+ID="opensuse-tumbleweed"
+ID_LIKE="opensuse suse"
+VERSION_ID="20240115"
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	osType, err := updateOS(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, OpenSUSE)
+}
+
+func (s *linuxSuite) TestUpdateOSDebian(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(filename, []byte(`PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+NAME="Debian GNU/Linux"
+VERSION_ID="12"
+VERSION="12 (bookworm)"
+VERSION_CODENAME=bookworm
+ID=debian
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	osType, err := updateOS(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, Debian)
+}
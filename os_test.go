@@ -4,7 +4,9 @@
 package os
 
 import (
+	"encoding/json"
 	"runtime"
+	"strings"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -26,7 +28,7 @@ func (s *osSuite) TestHostOS(c *gc.C) {
 		// TODO(mjs) - this should really do more by patching out
 		// osReleaseFile and testing the corner cases.
 		switch os {
-		case Ubuntu, CentOS, GenericLinux:
+		case Ubuntu, CentOS, GenericLinux, Debian, Alpine:
 		case OpenSUSE:
 			c.Assert(os, gc.Equals, OpenSUSE)
 		default:
@@ -56,8 +58,180 @@ func (s *osSuite) TestIsLinux(c *gc.C) {
 	c.Check(CentOS.IsLinux(), jc.IsTrue)
 	c.Check(GenericLinux.IsLinux(), jc.IsTrue)
 	c.Check(OpenSUSE.IsLinux(), jc.IsTrue)
+	c.Check(Debian.IsLinux(), jc.IsTrue)
+	c.Check(Alpine.IsLinux(), jc.IsTrue)
 
 	c.Check(OSX.IsLinux(), jc.IsFalse)
 	c.Check(Windows.IsLinux(), jc.IsFalse)
 	c.Check(Unknown.IsLinux(), jc.IsFalse)
 }
+
+func (s *osSuite) TestFamily(c *gc.C) {
+	expected := map[OSType]OSFamily{
+		Unknown:      OtherFamily,
+		Ubuntu:       DebianFamily,
+		Windows:      WindowsFamily,
+		OSX:          DarwinFamily,
+		CentOS:       RHELFamily,
+		GenericLinux: OtherFamily,
+		OpenSUSE:     SUSEFamily,
+		Kubernetes:   OtherFamily,
+		Debian:       DebianFamily,
+		Alpine:       OtherFamily,
+	}
+	c.Assert(expected, gc.HasLen, len(osTypes))
+	for _, t := range osTypes {
+		c.Logf("%s", t)
+		family, ok := expected[t]
+		c.Assert(ok, jc.IsTrue)
+		c.Check(t.Family(), gc.Equals, family)
+	}
+}
+
+func (s *osSuite) TestFamilyString(c *gc.C) {
+	c.Check(DebianFamily.String(), gc.Equals, "Debian")
+	c.Check(RHELFamily.String(), gc.Equals, "RHEL")
+	c.Check(SUSEFamily.String(), gc.Equals, "SUSE")
+	c.Check(DarwinFamily.String(), gc.Equals, "Darwin")
+	c.Check(WindowsFamily.String(), gc.Equals, "Windows")
+	c.Check(OtherFamily.String(), gc.Equals, "Other")
+}
+
+func (s *osSuite) TestOSTypeForName(c *gc.C) {
+	for _, test := range []struct {
+		name     string
+		expected OSType
+	}{
+		{"ubuntu", Ubuntu},
+		{"Ubuntu", Ubuntu},
+		{"UBUNTU", Ubuntu},
+		{"windows", Windows},
+		{"osx", OSX},
+		{"centos", CentOS},
+		{"genericlinux", GenericLinux},
+		{"opensuse", OpenSUSE},
+		{"kubernetes", Kubernetes},
+		{"debian", Debian},
+		{"alpine", Alpine},
+		{"macos", OSX},
+		{"macOS", OSX},
+		{"darwin", OSX},
+		{"linux", GenericLinux},
+		{"Linux", GenericLinux},
+	} {
+		c.Logf("name %q", test.name)
+		t, err := OSTypeForName(test.name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(t, gc.Equals, test.expected)
+	}
+}
+
+func (s *osSuite) TestOSTypeForNameUnknown(c *gc.C) {
+	for _, name := range []string{"plan9", "", "unknown", "ubuntu linux", "rhel"} {
+		c.Logf("name %q", name)
+		t, err := OSTypeForName(name)
+		c.Assert(err, gc.ErrorMatches, `unknown OS type ".*"`)
+		c.Check(t, gc.Equals, Unknown)
+	}
+}
+
+func (s *osSuite) TestOSTypeForFriendlyName(c *gc.C) {
+	for _, test := range []struct {
+		name     string
+		expected OSType
+	}{
+		{"Ubuntu", Ubuntu},
+		{"ubuntu linux", Ubuntu},
+		{"UBUNTU", Ubuntu},
+		{"Cent OS", CentOS},
+		{"CentOS Linux", CentOS},
+		{"RHEL", CentOS},
+		{"Red Hat Enterprise Linux", CentOS},
+		{"openSUSE Leap", OpenSUSE},
+		{"macOS", OSX},
+		{"Mac OS X", OSX},
+		{"Windows Server", Windows},
+		{"linux", GenericLinux},
+		{"generic-linux", GenericLinux},
+		{"k8s", Kubernetes},
+		{"Debian", Debian},
+		{"Alpine Linux", Alpine},
+	} {
+		c.Logf("name %q", test.name)
+		t, err := OSTypeForFriendlyName(test.name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(t, gc.Equals, test.expected)
+	}
+}
+
+func (s *osSuite) TestOSTypeForFriendlyNameUnknown(c *gc.C) {
+	t, err := OSTypeForFriendlyName("plan9")
+	c.Assert(err, gc.ErrorMatches, `unknown OS name "plan9"`)
+	c.Check(t, gc.Equals, Unknown)
+
+	_, err = OSTypeForFriendlyName("")
+	c.Assert(err, gc.ErrorMatches, `unknown OS name ""`)
+}
+
+func (s *osSuite) TestJSONRoundTrip(c *gc.C) {
+	for _, t := range []OSType{Unknown, Ubuntu, Windows, OSX, CentOS, GenericLinux, OpenSUSE, Kubernetes, Debian, Alpine} {
+		c.Logf("%s", t)
+		data, err := json.Marshal(t)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, `"`+strings.ToLower(t.String())+`"`)
+
+		var parsed OSType
+		err = json.Unmarshal(data, &parsed)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, t)
+	}
+}
+
+func (s *osSuite) TestJSONInStruct(c *gc.C) {
+	type machine struct {
+		OS OSType `json:"os"`
+	}
+	data, err := json.Marshal(machine{OS: CentOS})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"os":"centos"}`)
+
+	var m machine
+	err = json.Unmarshal([]byte(`{"os":"OSX"}`), &m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.OS, gc.Equals, OSX)
+}
+
+func (s *osSuite) TestUnmarshalJSONInvalid(c *gc.C) {
+	t := Ubuntu
+	err := json.Unmarshal([]byte(`"plan9"`), &t)
+	c.Assert(err, gc.ErrorMatches, `unknown OS type "plan9"`)
+	c.Check(t, gc.Equals, Unknown)
+
+	err = json.Unmarshal([]byte(`2`), &t)
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *osSuite) TestTextRoundTrip(c *gc.C) {
+	for _, t := range []OSType{Unknown, Ubuntu, Windows, OSX, CentOS, GenericLinux, OpenSUSE, Kubernetes, Debian, Alpine} {
+		c.Logf("%s", t)
+		text, err := t.MarshalText()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(text), gc.Equals, strings.ToLower(t.String()))
+
+		var parsed OSType
+		err = parsed.UnmarshalText(text)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, t)
+	}
+}
+
+func (s *osSuite) TestUnmarshalText(c *gc.C) {
+	var t OSType
+	err := t.UnmarshalText([]byte("CentOS"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t, gc.Equals, CentOS)
+
+	err = t.UnmarshalText([]byte("plan9"))
+	c.Assert(err, gc.ErrorMatches, `unknown OS type "plan9"`)
+	c.Check(t, gc.Equals, Unknown)
+}
@@ -0,0 +1,12 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// AppArmorProfileFor returns the AppArmor profile loaded for the binary, in
+// the form the kernel reports it, such as "/usr/sbin/tcpdump (enforce)". An
+// empty string is returned if the binary is unconfined, including when
+// AppArmor isn't enabled on the host.
+func AppArmorProfileFor(binaryPath string) (string, error) {
+	return defaultHost.AppArmorProfileFor(binaryPath)
+}
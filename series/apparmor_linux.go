@@ -0,0 +1,48 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// appArmorProfilesFile is the name of the file that is read in order to
+// determine the loaded AppArmor profiles (overrideable for testing).
+var appArmorProfilesFile = "/sys/kernel/security/apparmor/profiles"
+
+// AppArmorProfileFor returns the AppArmor profile loaded on the host for the
+// binary, and its mode.
+func (h *Host) AppArmorProfileFor(binaryPath string) (string, error) {
+	f, err := os.Open(h.path(appArmorProfilesFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Each line holds a profile name and its mode, such as
+		// "/usr/sbin/tcpdump (enforce)".
+		line := strings.TrimSpace(scanner.Text())
+		i := strings.LastIndex(line, " (")
+		if i < 0 {
+			continue
+		}
+		if line[:i] == binaryPath {
+			return line, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Annotatef(err, "reading %s", h.path(appArmorProfilesFile))
+	}
+	return "", nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type appArmorSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&appArmorSuite{})
+
+const appArmorProfiles = `lsb_release (enforce)
+nvidia_modprobe (enforce)
+/usr/sbin/tcpdump (enforce)
+/usr/bin/man (enforce)
+/usr/bin/man//man_groff (enforce)
+/usr/sbin/cups-browsed (complain)
+`
+
+func (s *appArmorSuite) TestAppArmorProfileFor(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.AppArmorProfilesFile, appArmorProfiles)
+	for _, test := range []struct {
+		binary   string
+		expected string
+	}{
+		{"/usr/sbin/tcpdump", "/usr/sbin/tcpdump (enforce)"},
+		{"/usr/sbin/cups-browsed", "/usr/sbin/cups-browsed (complain)"},
+		{"/usr/bin/man", "/usr/bin/man (enforce)"},
+		{"/usr/bin/ls", ""},
+	} {
+		profile, err := series.AppArmorProfileFor(test.binary)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(profile, gc.Equals, test.expected, gc.Commentf("%s", test.binary))
+	}
+}
+
+func (s *appArmorSuite) TestAppArmorProfileForNotEnabled(c *gc.C) {
+	s.PatchValue(series.AppArmorProfilesFile, filepath.Join(c.MkDir(), "profiles"))
+
+	profile, err := series.AppArmorProfileFor("/usr/sbin/tcpdump")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(profile, gc.Equals, "")
+}
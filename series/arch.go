@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"runtime"
+)
+
+var (
+	// goarch is the architecture the current process was built for
+	// (overrideable for testing).
+	goarch = runtime.GOARCH
+)
+
+// jujuArches maps the Go architectures that differ from their Juju name
+// to the Juju name, which follows the Debian naming of the architecture.
+var jujuArches = map[string]string{
+	"386":     "i386",
+	"arm":     "armhf",
+	"ppc64le": "ppc64el",
+}
+
+// HostArch returns the Juju name of the architecture of the host, such as
+// "amd64", "arm64", "ppc64el" or "s390x". As the architecture is that of
+// the running process, there is no Host method.
+func HostArch() string {
+	if arch, ok := jujuArches[goarch]; ok {
+		return arch
+	}
+	return goarch
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type archSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&archSuite{})
+
+func (s *archSuite) TestHostArch(c *gc.C) {
+	for i, test := range []struct {
+		goarch   string
+		expected string
+	}{
+		{"amd64", "amd64"},
+		{"arm64", "arm64"},
+		{"ppc64le", "ppc64el"},
+		{"s390x", "s390x"},
+		{"riscv64", "riscv64"},
+		{"386", "i386"},
+		{"arm", "armhf"},
+	} {
+		c.Logf("%d: %s", i, test.goarch)
+		s.PatchValue(series.GOARCH, test.goarch)
+		c.Check(series.HostArch(), gc.Equals, test.expected)
+	}
+}
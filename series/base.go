@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// Base identifies an operating system release by its OS and channel, such
+// as "ubuntu@22.04", rather than by a series name such as "jammy".
+type Base struct {
+	OS os.OSType
+
+	// Channel is the track of the release, such as "22.04" or "9",
+	// optionally followed by a risk, such as "22.04/stable".
+	Channel string
+}
+
+// baseRisks holds the risks that a base channel may have.
+var baseRisks = map[string]bool{
+	"stable":    true,
+	"candidate": true,
+	"beta":      true,
+	"edge":      true,
+}
+
+// ParseBase parses a base of the form "<os>@<track>[/<risk>]", such as
+// "ubuntu@22.04" or "ubuntu@22.04/stable". The OS is matched as for
+// os.OSTypeForName.
+func ParseBase(s string) (Base, error) {
+	parts := strings.Split(s, "@")
+	if len(parts) != 2 {
+		return Base{}, errors.NotValidf("base %q", s)
+	}
+	osType, err := os.OSTypeForName(parts[0])
+	if err != nil {
+		return Base{}, errors.NotValidf("base %q with OS %q", s, parts[0])
+	}
+	track, risk := parts[1], ""
+	if i := strings.Index(track, "/"); i >= 0 {
+		track, risk = track[:i], track[i+1:]
+		if !baseRisks[risk] {
+			return Base{}, errors.NotValidf("base %q with risk %q", s, risk)
+		}
+	}
+	if track == "" {
+		return Base{}, errors.NotValidf("base %q without a track", s)
+	}
+	return Base{OS: osType, Channel: parts[1]}, nil
+}
+
+// String returns the base in the form that ParseBase accepts, such as
+// "ubuntu@22.04".
+func (b Base) String() string {
+	return strings.ToLower(b.OS.String()) + "@" + b.Channel
+}
+
+// track returns the track of the base's channel, such as "22.04" for
+// "22.04/stable".
+func (b Base) track() string {
+	return strings.SplitN(b.Channel, "/", 2)[0]
+}
+
+// SeriesToBase returns the base of the series, such as "ubuntu@22.04" for
+// jammy or "centos@9" for centos9. Only Ubuntu, CentOS, Debian and Alpine
+// series have a base.
+func SeriesToBase(series string) (Base, error) {
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return Base{}, errors.Trace(err)
+	}
+	switch osType {
+	case os.Ubuntu:
+		version, err := UbuntuSeriesVersion(series)
+		if err != nil {
+			return Base{}, errors.Trace(err)
+		}
+		return Base{OS: osType, Channel: strings.TrimSuffix(version, " LTS")}, nil
+	case os.CentOS:
+		// Amazon and Oracle Linux series have no base.
+		if version, ok := centosSeries[series]; ok {
+			return Base{OS: osType, Channel: strings.TrimPrefix(version, "centos")}, nil
+		}
+	case os.Debian:
+		return Base{OS: osType, Channel: strings.TrimPrefix(debianSeries[series], "debian")}, nil
+	case os.Alpine:
+		return Base{OS: osType, Channel: strings.TrimPrefix(alpineSeries[series], "alpine")}, nil
+	}
+	return Base{}, errors.NotSupportedf("base for %s series %q", osType, series)
+}
+
+// BaseToSeries returns the series of the base, such as jammy for
+// "ubuntu@22.04". The risk of the base's channel is ignored.
+func BaseToSeries(base Base) (string, error) {
+	track := base.track()
+	var versions map[string]string
+	switch base.OS {
+	case os.Ubuntu:
+		series, err := VersionSeries(track)
+		if err != nil {
+			return "", errors.Annotatef(err, "base %q", base)
+		}
+		return series, nil
+	case os.CentOS:
+		versions = centosSeries
+	case os.Debian:
+		versions = debianSeries
+	case os.Alpine:
+		versions = alpineSeries
+	default:
+		return "", errors.NotSupportedf("series for base %q", base)
+	}
+	series := strings.ToLower(base.OS.String()) + track
+	if _, ok := versions[series]; !ok {
+		return "", errors.NotFoundf("series for base %q", base)
+	}
+	return series, nil
+}
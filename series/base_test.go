@@ -0,0 +1,136 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type baseSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&baseSuite{})
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+}
+
+func (s *baseSuite) TestParseBase(c *gc.C) {
+	for i, test := range []struct {
+		base     string
+		expected series.Base
+	}{
+		{"ubuntu@22.04", series.Base{OS: os.Ubuntu, Channel: "22.04"}},
+		{"ubuntu@22.04/stable", series.Base{OS: os.Ubuntu, Channel: "22.04/stable"}},
+		{"Ubuntu@24.04/edge", series.Base{OS: os.Ubuntu, Channel: "24.04/edge"}},
+		{"centos@9", series.Base{OS: os.CentOS, Channel: "9"}},
+		{"debian@12", series.Base{OS: os.Debian, Channel: "12"}},
+	} {
+		c.Logf("%d: %s", i, test.base)
+		base, err := series.ParseBase(test.base)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(base, gc.Equals, test.expected)
+	}
+}
+
+func (s *baseSuite) TestParseBaseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		base string
+		err  string
+	}{
+		{"", `base "" not valid`},
+		{"jammy", `base "jammy" not valid`},
+		{"ubuntu@22.04@24.04", `base "ubuntu@22.04@24.04" not valid`},
+		{"plan9@4", `base "plan9@4" with OS "plan9" not valid`},
+		{"ubuntu@", `base "ubuntu@" without a track not valid`},
+		{"ubuntu@/stable", `base "ubuntu@/stable" without a track not valid`},
+		{"ubuntu@22.04/daily", `base "ubuntu@22.04/daily" with risk "daily" not valid`},
+	} {
+		c.Logf("%d: %s", i, test.base)
+		_, err := series.ParseBase(test.base)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *baseSuite) TestString(c *gc.C) {
+	c.Check(series.Base{OS: os.Ubuntu, Channel: "22.04"}.String(), gc.Equals, "ubuntu@22.04")
+	c.Check(series.Base{OS: os.CentOS, Channel: "9/stable"}.String(), gc.Equals, "centos@9/stable")
+}
+
+func (s *baseSuite) TestRoundTrip(c *gc.C) {
+	for i, test := range []struct {
+		series string
+		base   string
+	}{
+		{"focal", "ubuntu@20.04"},
+		{"jammy", "ubuntu@22.04"},
+		{"noble", "ubuntu@24.04"},
+		{"kinetic", "ubuntu@22.10"},
+		{"centos7", "centos@7"},
+		{"centos9", "centos@9"},
+		{"debian12", "debian@12"},
+		{"alpine3.18", "alpine@3.18"},
+	} {
+		c.Logf("%d: %s", i, test.series)
+		base, err := series.SeriesToBase(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(base.String(), gc.Equals, test.base)
+
+		parsed, err := series.ParseBase(base.String())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, base)
+
+		result, err := series.BaseToSeries(parsed)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.series)
+	}
+}
+
+func (s *baseSuite) TestBaseToSeriesIgnoresRisk(c *gc.C) {
+	base, err := series.ParseBase("ubuntu@22.04/stable")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := series.BaseToSeries(base)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "jammy")
+
+	base, err = series.ParseBase("centos@9/candidate")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = series.BaseToSeries(base)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "centos9")
+}
+
+func (s *baseSuite) TestBaseToSeriesUnknown(c *gc.C) {
+	_, err := series.BaseToSeries(series.Base{OS: os.Ubuntu, Channel: "95.04"})
+	c.Check(err, gc.ErrorMatches, `base "ubuntu@95.04": unknown series for version: "95.04"`)
+
+	_, err = series.BaseToSeries(series.Base{OS: os.CentOS, Channel: "6"})
+	c.Check(err, gc.ErrorMatches, `series for base "centos@6" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = series.BaseToSeries(series.Base{OS: os.Windows, Channel: "2019"})
+	c.Check(err, gc.ErrorMatches, `series for base "windows@2019" not supported`)
+}
+
+func (s *baseSuite) TestSeriesToBaseNotSupported(c *gc.C) {
+	_, err := series.SeriesToBase("win2019")
+	c.Check(err, gc.ErrorMatches, `base for Windows series "win2019" not supported`)
+
+	_, err = series.SeriesToBase("amzn2023")
+	c.Check(err, gc.ErrorMatches, `base for CentOS series "amzn2023" not supported`)
+
+	_, err = series.SeriesToBase("firewolf")
+	c.Check(err, jc.Satisfies, series.IsUnknownOSForSeriesError)
+}
@@ -0,0 +1,24 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// BlockDevice describes a block device of the host.
+type BlockDevice struct {
+	// Name is the kernel name of the device, such as "sda" or "nvme0n1".
+	Name string
+
+	// Size is the size of the device in bytes.
+	Size uint64
+
+	// Rotational is true if the device is a spinning disk.
+	Rotational bool
+
+	// Removable is true if the device has removable media.
+	Removable bool
+}
+
+// BlockDevices returns the block devices of the host, sorted by name.
+func BlockDevices() ([]BlockDevice, error) {
+	return defaultHost.BlockDevices()
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// sysBlockDir is the directory that is read in order to determine the
+// block devices of the host (overrideable for testing).
+var sysBlockDir = "/sys/block"
+
+// sectorSize is the unit of the sysfs size file, which is always 512 bytes
+// regardless of the logical block size of the device.
+const sectorSize = 512
+
+// BlockDevices returns the block devices of the host, sorted by name.
+func (h *Host) BlockDevices() ([]BlockDevice, error) {
+	dir := h.path(sysBlockDir)
+	// ReadDir sorts the entries by name.
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var devices []BlockDevice
+	for _, entry := range entries {
+		device := BlockDevice{Name: entry.Name()}
+		sectors, err := readSysfsUint(filepath.Join(dir, entry.Name(), "size"))
+		if err != nil {
+			return nil, errors.Annotatef(err, "block device %q", entry.Name())
+		}
+		device.Size = sectors * sectorSize
+		rotational, err := readSysfsUint(filepath.Join(dir, entry.Name(), "queue", "rotational"))
+		if err == nil {
+			device.Rotational = rotational == 1
+		}
+		removable, err := readSysfsUint(filepath.Join(dir, entry.Name(), "removable"))
+		if err == nil {
+			device.Removable = removable == 1
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// readSysfsUint reads a sysfs attribute holding an unsigned integer.
+func readSysfsUint(path string) (uint64, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	value := strings.TrimSpace(string(contents))
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.NotValidf("%s value %q", filepath.Base(path), value)
+	}
+	return n, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type blockDevicesSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&blockDevicesSuite{})
+
+func (s *blockDevicesSuite) TestBlockDevices(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"sda/size":                 "1953525168\n",
+		"sda/removable":            "0\n",
+		"sda/queue/rotational":     "1\n",
+		"nvme0n1/size":             "1000215216\n",
+		"nvme0n1/removable":        "0\n",
+		"nvme0n1/queue/rotational": "0\n",
+		"sdb/size":                 "30031872\n",
+		"sdb/removable":            "1\n",
+		"sdb/queue/rotational":     "0\n",
+	})
+	s.PatchValue(series.SysBlockDir, root)
+
+	devices, err := series.BlockDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(devices, jc.DeepEquals, []series.BlockDevice{{
+		Name: "nvme0n1",
+		Size: 1000215216 * 512,
+	}, {
+		Name:       "sda",
+		Size:       1953525168 * 512,
+		Rotational: true,
+	}, {
+		Name:      "sdb",
+		Size:      30031872 * 512,
+		Removable: true,
+	}})
+}
+
+func (s *blockDevicesSuite) TestBlockDevicesBadSize(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"sda/size": "lots\n",
+	})
+	s.PatchValue(series.SysBlockDir, root)
+
+	_, err := series.BlockDevices()
+	c.Assert(err, gc.ErrorMatches, `block device "sda": size value "lots" not valid`)
+}
+
+func (s *blockDevicesSuite) TestBlockDevicesNoSysfs(c *gc.C) {
+	s.PatchValue(series.SysBlockDir, filepath.Join(c.MkDir(), "block"))
+
+	_, err := series.BlockDevices()
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
@@ -0,0 +1,17 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// SwapAccountingEnabled returns true if the kernel accounts for swap usage
+// in cgroups, which is required to limit the swap used by containers.
+func SwapAccountingEnabled() (bool, error) {
+	return defaultHost.SwapAccountingEnabled()
+}
+
+// UnifiedCgroupHierarchy returns true if the host was booted with only the
+// unified (v2) cgroup hierarchy, as opposed to the legacy or hybrid
+// hierarchies that mount cgroup v1 controllers.
+func UnifiedCgroupHierarchy() (bool, error) {
+	return defaultHost.UnifiedCgroupHierarchy()
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// cgroupRoot is the directory the cgroup hierarchy is mounted on
+	// (overrideable for testing).
+	cgroupRoot = "/sys/fs/cgroup"
+
+	// kernelCmdlineFile is the name of the file that is read in order to
+	// determine the kernel command line (overrideable for testing).
+	kernelCmdlineFile = "/proc/cmdline"
+)
+
+// cgroupV2 returns true if the unified (v2) cgroup hierarchy is mounted on
+// the cgroup root.
+func (h *Host) cgroupV2() bool {
+	_, err := os.Stat(filepath.Join(h.path(cgroupRoot), "cgroup.controllers"))
+	return err == nil
+}
+
+// SwapAccountingEnabled returns true if the host's kernel accounts for swap
+// usage in cgroups.
+func (h *Host) SwapAccountingEnabled() (bool, error) {
+	root := h.path(cgroupRoot)
+	if _, err := os.Stat(root); err != nil {
+		return false, errors.Trace(err)
+	}
+	if h.cgroupV2() {
+		// Inside a cgroup namespace the root is itself a non-root cgroup
+		// with its own memory.swap.max, and may have no children.
+		if _, err := os.Stat(filepath.Join(root, "memory.swap.max")); err == nil {
+			return true, nil
+		}
+		// The host's root cgroup has no memory.swap.* files, so look at
+		// its children.
+		matches, err := filepath.Glob(filepath.Join(root, "*", "memory.swap.max"))
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return len(matches) > 0, nil
+	}
+	// On cgroup v1 the memsw files only exist when the kernel is booted
+	// with swapaccount=1.
+	_, err := os.Stat(filepath.Join(root, "memory", "memory.memsw.limit_in_bytes"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// UnifiedCgroupHierarchy returns true if the host was booted with only the
+// unified cgroup hierarchy. That is the case when the cgroup root is a
+// cgroup2 mount; in the hybrid hierarchy it is a tmpfs, with cgroup2 mounted
+// beneath it. If the mounts don't include the cgroup root, the
+// systemd.unified_cgroup_hierarchy kernel parameter is consulted instead.
+func (h *Host) UnifiedCgroupHierarchy() (bool, error) {
+	m, err := h.mountFor(cgroupRoot)
+	if err == nil {
+		return m.FSType == "cgroup2", nil
+	} else if !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+
+	contents, err := ioutil.ReadFile(h.path(kernelCmdlineFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	unified := false
+	for _, param := range strings.Fields(string(contents)) {
+		name, value := param, "1"
+		if i := strings.Index(param, "="); i >= 0 {
+			name, value = param[:i], param[i+1:]
+		}
+		if name != "systemd.unified_cgroup_hierarchy" {
+			continue
+		}
+		// The last occurrence of a parameter takes precedence.
+		switch strings.ToLower(value) {
+		case "1", "yes", "true", "on":
+			unified = true
+		default:
+			unified = false
+		}
+	}
+	return unified, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type cgroupSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&cgroupSuite{})
+
+func (s *cgroupSuite) TestSwapAccountingEnabled(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		files    []string
+		expected bool
+	}{{
+		message: "v2 with swap accounting",
+		files: []string{
+			"cgroup.controllers",
+			"system.slice/memory.max",
+			"system.slice/memory.swap.max",
+		},
+		expected: true,
+	}, {
+		message: "v2 in a cgroup namespace with swap accounting",
+		files: []string{
+			"cgroup.controllers",
+			"memory.max",
+			"memory.swap.max",
+		},
+		expected: true,
+	}, {
+		message: "v2 without swap accounting",
+		files: []string{
+			"cgroup.controllers",
+			"system.slice/memory.max",
+		},
+		expected: false,
+	}, {
+		message: "v1 with swap accounting",
+		files: []string{
+			"memory/memory.limit_in_bytes",
+			"memory/memory.memsw.limit_in_bytes",
+		},
+		expected: true,
+	}, {
+		message: "v1 without swap accounting",
+		files: []string{
+			"memory/memory.limit_in_bytes",
+		},
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		files := make(map[string]string)
+		for _, file := range test.files {
+			files[file] = ""
+		}
+		s.PatchValue(series.CgroupRoot, makeHostRoot(c, files))
+
+		enabled, err := series.SwapAccountingEnabled()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(enabled, gc.Equals, test.expected)
+	}
+}
+
+func (s *cgroupSuite) TestSwapAccountingEnabledNoCgroups(c *gc.C) {
+	s.PatchValue(series.CgroupRoot, filepath.Join(c.MkDir(), "cgroup"))
+
+	_, err := series.SwapAccountingEnabled()
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *cgroupSuite) TestUnifiedCgroupHierarchy(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		mounts   string
+		expected bool
+	}{{
+		message:  "unified",
+		mounts:   "sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0\ncgroup2 /sys/fs/cgroup cgroup2 rw,nosuid,nodev,noexec,relatime,nsdelegate 0 0\n",
+		expected: true,
+	}, {
+		message: "hybrid",
+		mounts: "tmpfs /sys/fs/cgroup tmpfs ro,nosuid,nodev,noexec,mode=755 0 0\n" +
+			"cgroup2 /sys/fs/cgroup/unified cgroup2 rw,nosuid,nodev,noexec,relatime,nsdelegate 0 0\n" +
+			"cgroup /sys/fs/cgroup/memory cgroup rw,nosuid,nodev,noexec,relatime,memory 0 0\n",
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.MountsFile, test.mounts)
+
+		unified, err := series.UnifiedCgroupHierarchy()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(unified, gc.Equals, test.expected)
+	}
+}
+
+func (s *cgroupSuite) TestUnifiedCgroupHierarchyKernelCmdline(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.MountsFile, "sysfs /sys sysfs rw 0 0\n")
+	for i, test := range []struct {
+		cmdline  string
+		expected bool
+	}{
+		{"BOOT_IMAGE=/vmlinuz root=/dev/sda1 ro systemd.unified_cgroup_hierarchy=1\n", true},
+		{"BOOT_IMAGE=/vmlinuz root=/dev/sda1 ro systemd.unified_cgroup_hierarchy\n", true},
+		{"BOOT_IMAGE=/vmlinuz systemd.unified_cgroup_hierarchy=1 systemd.unified_cgroup_hierarchy=0\n", false},
+		{"BOOT_IMAGE=/vmlinuz root=/dev/sda1 ro quiet splash\n", false},
+	} {
+		c.Logf("%d: %s", i, test.cmdline)
+		patchFile(c, &s.CleanupSuite, series.KernelCmdlineFile, test.cmdline)
+
+		unified, err := series.UnifiedCgroupHierarchy()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(unified, gc.Equals, test.expected)
+	}
+}
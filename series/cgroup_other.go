@@ -0,0 +1,21 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+// SwapAccountingEnabled is only supported on Linux.
+func (h *Host) SwapAccountingEnabled() (bool, error) {
+	return false, errors.NotSupportedf("cgroup detection")
+}
+
+// UnifiedCgroupHierarchy is only supported on Linux.
+func (h *Host) UnifiedCgroupHierarchy() (bool, error) {
+	return false, errors.NotSupportedf("cgroup detection")
+}
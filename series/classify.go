@@ -0,0 +1,184 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// ClassifyInput holds the information gathered from a machine, such as over
+// SSH or WinRM, or from a mounted image, that identifies its series. Only
+// one kind of information needs to be provided; if several are, they are
+// consulted in the order of the fields.
+type ClassifyInput struct {
+	// OSRelease holds the contents of the os-release file of a Linux
+	// machine.
+	OSRelease string
+
+	// SwVers holds the output of "sw_vers" or "sw_vers -productVersion"
+	// on an OSX machine.
+	SwVers string
+
+	// Uname holds the output of "uname -r" on an OSX machine.
+	Uname string
+
+	// WindowsBuild holds the build number of a Windows machine, such as
+	// 17763, and WindowsServer whether it runs a server edition.
+	WindowsBuild  int
+	WindowsServer bool
+}
+
+// ClassifyResult holds the series that ClassifyInput identifies.
+type ClassifyResult struct {
+	// Series is the series of the machine, such as "jammy".
+	Series string
+
+	// OSType is the operating system of the machine.
+	OSType os.OSType
+
+	// Version is the version of the operating system, such as "22.04"
+	// for Ubuntu, "14" for OSX or the build number for Windows.
+	Version string
+
+	// Source names the input the series was resolved from: "os-release",
+	// "sw_vers", "uname" or "windows-build".
+	Source string
+}
+
+// Classify returns the series of a machine from the information gathered
+// from it.
+func Classify(input ClassifyInput) (ClassifyResult, error) {
+	var (
+		result ClassifyResult
+		err    error
+	)
+	switch {
+	case input.OSRelease != "":
+		result, err = classifyOSRelease(input.OSRelease)
+	case input.SwVers != "":
+		result, err = classifySwVers(input.SwVers)
+	case input.Uname != "":
+		result, err = classifyUname(input.Uname)
+	case input.WindowsBuild != 0:
+		result, err = classifyWindowsBuild(input.WindowsBuild, input.WindowsServer)
+	default:
+		return ClassifyResult{}, errors.NotValidf("empty classify input")
+	}
+	if err != nil {
+		return ClassifyResult{}, errors.Trace(err)
+	}
+	if result.OSType, err = GetOSFromSeries(result.Series); err != nil {
+		return ClassifyResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func classifyOSRelease(contents string) (ClassifyResult, error) {
+	values, err := parseOSRelease(contents)
+	if err != nil {
+		return ClassifyResult{}, errors.Trace(err)
+	}
+	series, err := seriesFromReleaseValues(values)
+	if err != nil {
+		return ClassifyResult{}, errors.Annotatef(err, "os-release %s %s", values["ID"], values["VERSION_ID"])
+	}
+	return ClassifyResult{
+		Series:  series,
+		Version: values["VERSION_ID"],
+		Source:  "os-release",
+	}, nil
+}
+
+func classifySwVers(output string) (ClassifyResult, error) {
+	// The full output has a line such as "ProductVersion:	14.5"; with
+	// -productVersion it is just the version.
+	version := strings.TrimSpace(output)
+	for _, line := range strings.Split(output, "\n") {
+		if parts := strings.SplitN(line, ":", 2); len(parts) == 2 && strings.TrimSpace(parts[0]) == "ProductVersion" {
+			version = strings.TrimSpace(parts[1])
+		}
+	}
+	series, err := MacOSSeriesFromProductVersion(version)
+	if err != nil {
+		return ClassifyResult{}, errors.Trace(err)
+	}
+	return ClassifyResult{
+		Series:  series,
+		Version: version,
+		Source:  "sw_vers",
+	}, nil
+}
+
+func classifyUname(output string) (ClassifyResult, error) {
+	release := strings.TrimSpace(output)
+	darwinMajor, err := kernelToMajor(func() (string, error) {
+		return release, nil
+	})
+	if err != nil {
+		return ClassifyResult{}, errors.NotValidf("kernel release %q", release)
+	}
+	series, err := macOSXSeriesFromMajorVersion(darwinMajor)
+	if err != nil {
+		return ClassifyResult{}, errors.Trace(err)
+	}
+	// Since Big Sur (Darwin 20) the macOS major version is 9 less than the
+	// Darwin one; before that macOS was 10.x, with x 4 less.
+	version := strconv.Itoa(darwinMajor - 9)
+	if darwinMajor < 20 {
+		version = "10." + strconv.Itoa(darwinMajor-4)
+	}
+	return ClassifyResult{
+		Series:  series,
+		Version: version,
+		Source:  "uname",
+	}, nil
+}
+
+// windowsBuildSeries maps the build number of each Windows release to its
+// client and server series. Client builds that aren't listed are the win10
+// series, or the win11 series from its first build.
+var windowsBuildSeries = map[int]struct{ client, server string }{
+	7600:  {"win7", "win2008r2"},
+	7601:  {"win7", "win2008r2"},
+	9200:  {"win8", "win2012"},
+	9600:  {"win81", "win2012r2"},
+	14393: {"win10", "win2016"},
+	17763: {"win10", "win2019"},
+	20348: {"", "win2022"},
+}
+
+const (
+	// firstWindows10Build is the build number of the first Windows 10
+	// release.
+	firstWindows10Build = 10240
+
+	// firstWindows11Build is the build number of the first Windows 11
+	// release, which still calls itself Windows 10 in the registry.
+	firstWindows11Build = 22000
+)
+
+func classifyWindowsBuild(build int, server bool) (ClassifyResult, error) {
+	names, ok := windowsBuildSeries[build]
+	if !ok && !server && build >= firstWindows11Build {
+		names.client, ok = "win11", true
+	} else if !ok && !server && build >= firstWindows10Build {
+		names.client, ok = "win10", true
+	}
+	series := names.client
+	if server {
+		series = names.server
+	}
+	if !ok || series == "" {
+		return ClassifyResult{}, errors.NotFoundf("windows series for build %d", build)
+	}
+	return ClassifyResult{
+		Series:  series,
+		Version: strconv.Itoa(build),
+		Source:  "windows-build",
+	}, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type classifySuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&classifySuite{})
+
+func (s *classifySuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+}
+
+func (s *classifySuite) TestClassify(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		input    series.ClassifyInput
+		expected series.ClassifyResult
+	}{{
+		message: "ubuntu os-release",
+		input: series.ClassifyInput{
+			OSRelease: "NAME=\"Ubuntu\"\nVERSION_ID=\"22.04\"\nID=ubuntu\nID_LIKE=debian\n",
+		},
+		expected: series.ClassifyResult{Series: "jammy", OSType: os.Ubuntu, Version: "22.04", Source: "os-release"},
+	}, {
+		message:  "centos os-release",
+		input:    series.ClassifyInput{OSRelease: "ID=\"centos\"\nVERSION_ID=\"7\"\n"},
+		expected: series.ClassifyResult{Series: "centos7", OSType: os.CentOS, Version: "7", Source: "os-release"},
+	}, {
+		message:  "unknown os-release",
+		input:    series.ClassifyInput{OSRelease: "ID=gentoo\nVERSION_ID=2.14\n"},
+		expected: series.ClassifyResult{Series: "genericlinux", OSType: os.GenericLinux, Version: "2.14", Source: "os-release"},
+	}, {
+		message: "sw_vers",
+		input: series.ClassifyInput{
+			SwVers: "ProductName:\t\tmacOS\nProductVersion:\t\t14.5\nBuildVersion:\t\t23F79\n",
+		},
+		expected: series.ClassifyResult{Series: "sonoma", OSType: os.OSX, Version: "14.5", Source: "sw_vers"},
+	}, {
+		message:  "sw_vers product version",
+		input:    series.ClassifyInput{SwVers: "10.15.7\n"},
+		expected: series.ClassifyResult{Series: "catalina", OSType: os.OSX, Version: "10.15.7", Source: "sw_vers"},
+	}, {
+		message:  "sw_vers big sur",
+		input:    series.ClassifyInput{SwVers: "11.7\n"},
+		expected: series.ClassifyResult{Series: "bigsur", OSType: os.OSX, Version: "11.7", Source: "sw_vers"},
+	}, {
+		message:  "sw_vers sequoia",
+		input:    series.ClassifyInput{SwVers: "15.0\n"},
+		expected: series.ClassifyResult{Series: "sequoia", OSType: os.OSX, Version: "15.0", Source: "sw_vers"},
+	}, {
+		message:  "uname",
+		input:    series.ClassifyInput{Uname: "24.1.0\n"},
+		expected: series.ClassifyResult{Series: "sequoia", OSType: os.OSX, Version: "15", Source: "uname"},
+	}, {
+		message:  "uname before big sur",
+		input:    series.ClassifyInput{Uname: "18.7.0\n"},
+		expected: series.ClassifyResult{Series: "mojave", OSType: os.OSX, Version: "10.14", Source: "uname"},
+	}, {
+		message:  "windows server build",
+		input:    series.ClassifyInput{WindowsBuild: 17763, WindowsServer: true},
+		expected: series.ClassifyResult{Series: "win2019", OSType: os.Windows, Version: "17763", Source: "windows-build"},
+	}, {
+		message:  "windows client build",
+		input:    series.ClassifyInput{WindowsBuild: 19045},
+		expected: series.ClassifyResult{Series: "win10", OSType: os.Windows, Version: "19045", Source: "windows-build"},
+	}, {
+		message:  "windows server 2022 build",
+		input:    series.ClassifyInput{WindowsBuild: 20348, WindowsServer: true},
+		expected: series.ClassifyResult{Series: "win2022", OSType: os.Windows, Version: "20348", Source: "windows-build"},
+	}, {
+		message:  "windows 11 build",
+		input:    series.ClassifyInput{WindowsBuild: 22631},
+		expected: series.ClassifyResult{Series: "win11", OSType: os.Windows, Version: "22631", Source: "windows-build"},
+	}, {
+		message: "os-release takes precedence",
+		input: series.ClassifyInput{
+			OSRelease: "ID=ubuntu\nVERSION_ID=\"20.04\"\n",
+			Uname:     "24.1.0\n",
+		},
+		expected: series.ClassifyResult{Series: "focal", OSType: os.Ubuntu, Version: "20.04", Source: "os-release"},
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		result, err := series.Classify(test.input)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *classifySuite) TestClassifyErrors(c *gc.C) {
+	for i, test := range []struct {
+		input series.ClassifyInput
+		err   string
+	}{
+		{series.ClassifyInput{}, "empty classify input not valid"},
+		{series.ClassifyInput{OSRelease: "NAME=Ubuntu\n"}, "OS release file is missing ID"},
+		{series.ClassifyInput{OSRelease: "ID=ubuntu\nVERSION_ID=95.04\n"}, "os-release ubuntu 95.04: could not determine series"},
+		{series.ClassifyInput{SwVers: "99.0\n"}, `macOS series for version "99.0" not found`},
+		{series.ClassifyInput{Uname: "darwin\n"}, `kernel release "darwin" not valid`},
+		{series.ClassifyInput{WindowsBuild: 26100, WindowsServer: true}, "windows series for build 26100 not found"},
+	} {
+		c.Logf("%d: %+v", i, test.input)
+		_, err := series.Classify(test.input)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
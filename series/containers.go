@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// containerSockets holds the sockets of the supported container
+	// runtimes, in order of preference (overrideable for testing).
+	containerSockets = []string{
+		"/var/run/docker.sock",
+		"/run/containerd/containerd.sock",
+	}
+)
+
+// DefaultContainerSocket returns the socket of the container runtime that is
+// running, preferring Docker over containerd when both are. A NotFound error
+// is returned if no container runtime is running.
+func DefaultContainerSocket() (string, error) {
+	return defaultHost.DefaultContainerSocket()
+}
+
+// DefaultContainerSocket returns the socket of the container runtime that is
+// running on the host.
+func (h *Host) DefaultContainerSocket() (string, error) {
+	for _, socket := range containerSockets {
+		info, err := os.Stat(h.path(socket))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		if info.Mode()&os.ModeSocket != 0 {
+			return socket, nil
+		}
+	}
+	return "", errors.NotFoundf("container runtime socket")
+}
+
+// IsContainer returns true if the host is running inside a container, such
+// as an LXD, Docker or Podman container, rather than on a machine of its own.
+func IsContainer() (bool, error) {
+	return defaultHost.IsContainer()
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// containerEnvFiles holds the files that container runtimes create in
+	// the root filesystem of their containers (overrideable for testing).
+	containerEnvFiles = []string{
+		"/run/.containerenv",
+		"/.dockerenv",
+	}
+
+	// initEnvironFile is the name of the file that is read in order to
+	// determine the environment of PID 1 (overrideable for testing).
+	initEnvironFile = "/proc/1/environ"
+
+	// initCgroupFile is the name of the file that is read in order to
+	// determine the cgroups of PID 1 (overrideable for testing).
+	initCgroupFile = "/proc/1/cgroup"
+)
+
+// containerCgroups holds the cgroup path elements that identify the
+// container runtime that created a cgroup.
+var containerCgroups = []string{
+	"/docker",
+	"/system.slice/docker-",
+	"/lxc",
+	"/kubepods",
+	"/libpod",
+}
+
+// IsContainer returns true if the host is running inside a container. Podman
+// and Docker mark their containers with a file in the root filesystem; LXD
+// and systemd-nspawn set the container environment variable of PID 1. On
+// cgroup v1 hosts, the cgroups of PID 1 name the container runtime.
+func (h *Host) IsContainer() (bool, error) {
+	for _, file := range containerEnvFiles {
+		if _, err := os.Stat(h.path(file)); err == nil {
+			return true, nil
+		} else if !os.IsNotExist(err) {
+			return false, errors.Trace(err)
+		}
+	}
+
+	// The environment of PID 1 is only readable by root.
+	environ, err := ioutil.ReadFile(h.path(initEnvironFile))
+	if err != nil && !os.IsNotExist(err) && !os.IsPermission(err) {
+		return false, errors.Trace(err)
+	}
+	for _, variable := range bytes.Split(environ, []byte{0}) {
+		if bytes.HasPrefix(variable, []byte("container=")) {
+			return true, nil
+		}
+	}
+
+	cgroups, err := ioutil.ReadFile(h.path(initCgroupFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, line := range strings.Split(string(cgroups), "\n") {
+		// Each line is of the form "hierarchy-ID:controllers:path".
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		for _, prefix := range containerCgroups {
+			if strings.HasPrefix(fields[2], prefix) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
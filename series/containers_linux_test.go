@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type isContainerSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&isContainerSuite{})
+
+const bareMetalCgroups = `12:memory:/init.scope
+11:pids:/init.scope
+1:name=systemd:/init.scope
+0::/init.scope
+`
+
+func (s *isContainerSuite) TestIsContainer(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		files    map[string]string
+		expected bool
+	}{{
+		message: "lxd",
+		files: map[string]string{
+			"/proc/1/environ": "container=lxc\x00HOME=/\x00",
+			"/proc/1/cgroup":  "0::/init.scope\n",
+		},
+		expected: true,
+	}, {
+		message: "lxd with unreadable environment",
+		files: map[string]string{
+			"/proc/1/cgroup": "12:memory:/lxc.payload.juju-machine-0/init.scope\n0::/init.scope\n",
+		},
+		expected: true,
+	}, {
+		message: "docker",
+		files: map[string]string{
+			"/.dockerenv":    "",
+			"/proc/1/cgroup": "0::/\n",
+		},
+		expected: true,
+	}, {
+		message: "docker on cgroup v1",
+		files: map[string]string{
+			"/proc/1/cgroup": "12:memory:/docker/3601745b3bd5\n1:name=systemd:/docker/3601745b3bd5\n",
+		},
+		expected: true,
+	}, {
+		message: "podman",
+		files: map[string]string{
+			"/run/.containerenv": "",
+			"/proc/1/cgroup":     "0::/\n",
+		},
+		expected: true,
+	}, {
+		message: "bare metal",
+		files: map[string]string{
+			"/proc/1/environ": "HOME=/\x00TERM=linux\x00",
+			"/proc/1/cgroup":  bareMetalCgroups,
+		},
+		expected: false,
+	}, {
+		message:  "no proc",
+		files:    map[string]string{},
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		h := series.NewHost()
+		h.Root = makeHostRoot(c, test.files)
+
+		container, err := h.IsContainer()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(container, gc.Equals, test.expected)
+	}
+}
+
+func (s *isContainerSuite) TestIsContainerPatched(c *gc.C) {
+	dir := c.MkDir()
+	s.PatchValue(series.ContainerEnvFiles, []string{filepath.Join(dir, ".dockerenv")})
+	s.PatchValue(series.InitEnvironFile, filepath.Join(dir, "environ"))
+	patchFile(c, &s.CleanupSuite, series.InitCgroupFile, bareMetalCgroups)
+
+	container, err := series.IsContainer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container, jc.IsFalse)
+
+	patchFile(c, &s.CleanupSuite, series.InitEnvironFile, "container=systemd-nspawn\x00")
+	container, err = series.IsContainer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container, jc.IsTrue)
+}
@@ -0,0 +1,16 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+// IsContainer is only supported on Linux.
+func (h *Host) IsContainer() (bool, error) {
+	return false, errors.NotSupportedf("container detection")
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package series_test
+
+import (
+	"io/ioutil"
+	"net"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type containersSuite struct {
+	testing.CleanupSuite
+
+	docker, containerd string
+}
+
+var _ = gc.Suite(&containersSuite{})
+
+func (s *containersSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	dir := c.MkDir()
+	s.docker = filepath.Join(dir, "docker.sock")
+	s.containerd = filepath.Join(dir, "containerd.sock")
+	s.PatchValue(series.ContainerSockets, []string{s.docker, s.containerd})
+}
+
+func (s *containersSuite) listen(c *gc.C, socket string) {
+	l, err := net.Listen("unix", socket)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { _ = l.Close() })
+}
+
+func (s *containersSuite) TestDefaultContainerSocketDocker(c *gc.C) {
+	s.listen(c, s.docker)
+
+	socket, err := series.DefaultContainerSocket()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(socket, gc.Equals, s.docker)
+}
+
+func (s *containersSuite) TestDefaultContainerSocketContainerd(c *gc.C) {
+	s.listen(c, s.containerd)
+
+	socket, err := series.DefaultContainerSocket()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(socket, gc.Equals, s.containerd)
+}
+
+func (s *containersSuite) TestDefaultContainerSocketPrefersDocker(c *gc.C) {
+	s.listen(c, s.containerd)
+	s.listen(c, s.docker)
+
+	socket, err := series.DefaultContainerSocket()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(socket, gc.Equals, s.docker)
+}
+
+func (s *containersSuite) TestDefaultContainerSocketNotFound(c *gc.C) {
+	// A stale regular file isn't a socket.
+	err := ioutil.WriteFile(s.docker, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = series.DefaultContainerSocket()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// ErrNoSysctlConfigDir is returned by SysctlConfigDir for operating systems
+// that have no sysctl drop-in directory.
+var ErrNoSysctlConfigDir = errors.New("no sysctl config directory")
+
+// SyslogSocket returns the conventional path of the local syslog socket for
+// the OS. An empty string is returned for operating systems without one,
+// such as Windows.
+func SyslogSocket(osType os.OSType) string {
+	switch {
+	case osType.IsLinux():
+		return "/dev/log"
+	case osType == os.OSX:
+		return "/var/run/syslog"
+	}
+	return ""
+}
+
+// AdminGroup returns the group that administrators are conventionally added
+// to on the OS, granting them sudo rights. An empty string is returned for
+// operating systems without such a convention.
+func AdminGroup(osType os.OSType) string {
+	switch osType {
+	case os.Ubuntu, os.Debian:
+		return "sudo"
+	case os.CentOS, os.OpenSUSE, os.Alpine, os.OSX:
+		return "wheel"
+	}
+	return ""
+}
+
+// SysctlConfigDir returns the directory that kernel parameters are
+// conventionally dropped into on the OS, to be applied at boot. All the
+// supported Linux distributions use /etc/sysctl.d; ErrNoSysctlConfigDir is
+// returned for other operating systems.
+func SysctlConfigDir(osType os.OSType) (string, error) {
+	if osType.IsLinux() {
+		return "/etc/sysctl.d", nil
+	}
+	return "", ErrNoSysctlConfigDir
+}
+
+// docsURLs holds the documentation URL of each OS, used when the host
+// doesn't provide one.
+var docsURLs = map[os.OSType]string{
+	os.Ubuntu:     "https://www.ubuntu.com/",
+	os.CentOS:     "https://www.centos.org/",
+	os.OpenSUSE:   "https://www.opensuse.org/",
+	os.Debian:     "https://www.debian.org/",
+	os.Alpine:     "https://alpinelinux.org/",
+	os.Windows:    "https://www.microsoft.com/windows/",
+	os.OSX:        "https://www.apple.com/macos/",
+	os.Kubernetes: "https://kubernetes.io/docs/",
+}
+
+// DocsURL returns the conventional documentation URL for the OS. An empty
+// string is returned for operating systems without one, such as
+// GenericLinux.
+func DocsURL(osType os.OSType) string {
+	return docsURLs[osType]
+}
+
+// DocsURL returns the documentation URL for the OS. If the host is running
+// that OS and its os-release file has a HOME_URL, that is preferred over
+// the conventional URL returned by the DocsURL function.
+func (h *Host) DocsURL(osType os.OSType) string {
+	if hostOS, err := h.OS(); err == nil && hostOS == osType {
+		if url := h.homeURL(); url != "" {
+			return url
+		}
+	}
+	return DocsURL(osType)
+}
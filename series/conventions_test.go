@@ -0,0 +1,70 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type conventionsSuite struct{}
+
+var _ = gc.Suite(&conventionsSuite{})
+
+func (s *conventionsSuite) TestSyslogSocket(c *gc.C) {
+	for _, test := range []struct {
+		os       os.OSType
+		expected string
+	}{
+		{os.Ubuntu, "/dev/log"},
+		{os.CentOS, "/dev/log"},
+		{os.OpenSUSE, "/dev/log"},
+		{os.GenericLinux, "/dev/log"},
+		{os.OSX, "/var/run/syslog"},
+		{os.Windows, ""},
+		{os.Unknown, ""},
+	} {
+		c.Check(series.SyslogSocket(test.os), gc.Equals, test.expected, gc.Commentf("%s", test.os))
+	}
+}
+
+func (s *conventionsSuite) TestAdminGroup(c *gc.C) {
+	for _, test := range []struct {
+		os       os.OSType
+		expected string
+	}{
+		{os.Ubuntu, "sudo"},
+		{os.CentOS, "wheel"},
+		{os.OpenSUSE, "wheel"},
+		{os.OSX, "wheel"},
+		{os.GenericLinux, ""},
+		{os.Windows, ""},
+	} {
+		c.Check(series.AdminGroup(test.os), gc.Equals, test.expected, gc.Commentf("%s", test.os))
+	}
+}
+
+func (s *conventionsSuite) TestSysctlConfigDir(c *gc.C) {
+	for _, osType := range []os.OSType{os.Ubuntu, os.CentOS, os.OpenSUSE, os.GenericLinux} {
+		dir, err := series.SysctlConfigDir(osType)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(dir, gc.Equals, "/etc/sysctl.d", gc.Commentf("%s", osType))
+	}
+}
+
+func (s *conventionsSuite) TestSysctlConfigDirNotLinux(c *gc.C) {
+	for _, osType := range []os.OSType{os.OSX, os.Windows, os.Kubernetes, os.Unknown} {
+		_, err := series.SysctlConfigDir(osType)
+		c.Check(err, gc.Equals, series.ErrNoSysctlConfigDir, gc.Commentf("%s", osType))
+	}
+}
+
+func (s *conventionsSuite) TestDocsURL(c *gc.C) {
+	c.Check(series.DocsURL(os.Ubuntu), gc.Equals, "https://www.ubuntu.com/")
+	c.Check(series.DocsURL(os.CentOS), gc.Equals, "https://www.centos.org/")
+	c.Check(series.DocsURL(os.GenericLinux), gc.Equals, "")
+}
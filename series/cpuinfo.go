@@ -0,0 +1,130 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// CPUInfo returns the vendor and feature flags of the CPU of the machine the
+// current process is running on. The flags are lower case, so that "avx2",
+// "sse4_2" and "aes" can be checked for regardless of the host OS.
+//
+// On ARM the vendor is derived from the implementer code; implementers that
+// aren't known are reported as "ARM", meaning the architecture rather than
+// ARM Ltd. On Apple silicon the vendor is "Apple" and no flags are reported.
+func CPUInfo() (vendor string, flags []string, err error) {
+	return defaultHost.CPUInfo()
+}
+
+// CPUInfo returns the vendor and feature flags of the host's CPU.
+func (h *Host) CPUInfo() (vendor string, flags []string, err error) {
+	return h.readCPUInfo()
+}
+
+// parseCPUInfo parses the contents of /proc/cpuinfo. Only the first
+// processor block is consulted, as all processors are expected to be of the
+// same make.
+func parseCPUInfo(r io.Reader) (string, []string, error) {
+	var (
+		vendor, implementer string
+		flags               []string
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			// A blank line terminates the first processor block.
+			if vendor != "" || implementer != "" || flags != nil {
+				break
+			}
+			continue
+		}
+		c := strings.SplitN(line, ":", 2)
+		if len(c) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(c[0]), strings.TrimSpace(c[1])
+		switch key {
+		case "vendor_id":
+			vendor = value
+		case "CPU implementer":
+			implementer = value
+		case "flags", "Features":
+			flags = strings.Fields(strings.ToLower(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	// ARM processors don't report a vendor_id, only the implementer code.
+	if vendor == "" && implementer != "" {
+		vendor = armImplementer(implementer)
+	}
+	return vendor, flags, nil
+}
+
+// armImplementers maps the "CPU implementer" codes reported by the kernel
+// to the name of the implementer.
+var armImplementers = map[uint64]string{
+	0x41: "ARM",
+	0x42: "Broadcom",
+	0x43: "Cavium",
+	0x46: "Fujitsu",
+	0x48: "HiSilicon",
+	0x4e: "NVIDIA",
+	0x50: "APM",
+	0x51: "Qualcomm",
+	0x61: "Apple",
+	0xc0: "Ampere",
+}
+
+func armImplementer(code string) string {
+	value, err := strconv.ParseUint(code, 0, 8)
+	if err != nil {
+		return "ARM"
+	}
+	if name, ok := armImplementers[value]; ok {
+		return name
+	}
+	return "ARM"
+}
+
+// CPUCounts returns the number of CPUs of the host that are online, and the
+// number that are present, including those that are offline or could be
+// brought online by hotplug.
+func CPUCounts() (online int, present int, err error) {
+	return defaultHost.CPUCounts()
+}
+
+// parseCPURange returns the number of CPUs in a kernel CPU list, such as
+// "0-3,6".
+func parseCPURange(list string) (int, error) {
+	list = strings.TrimSpace(list)
+	count := 0
+	for _, part := range strings.Split(list, ",") {
+		if part == "" {
+			continue
+		}
+		first, last := part, part
+		if i := strings.Index(part, "-"); i >= 0 {
+			first, last = part[:i], part[i+1:]
+		}
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return 0, errors.NotValidf("cpu list %q", list)
+		}
+		to, err := strconv.Atoi(last)
+		if err != nil || to < from {
+			return 0, errors.NotValidf("cpu list %q", list)
+		}
+		count += to - from + 1
+	}
+	return count, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"strings"
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// sysctl reads a named sysctl value (overrideable for testing).
+	sysctl = syscall.Sysctl
+)
+
+func (h *Host) readCPUInfo() (string, []string, error) {
+	vendor, err := sysctl("machdep.cpu.vendor")
+	if err != nil {
+		// Apple silicon doesn't report a vendor.
+		brand, brandErr := sysctl("machdep.cpu.brand_string")
+		if brandErr != nil || !strings.HasPrefix(brand, "Apple") {
+			return "", nil, errors.Trace(err)
+		}
+		return "Apple", nil, nil
+	}
+	var flags []string
+	for _, name := range []string{"machdep.cpu.features", "machdep.cpu.leaf7_features"} {
+		value, err := sysctl(name)
+		if err != nil {
+			continue
+		}
+		// Darwin reports flags such as "SSE4.2", whereas Linux reports
+		// "sse4_2"; normalise on the Linux form.
+		value = strings.Replace(strings.ToLower(value), ".", "_", -1)
+		flags = append(flags, strings.Fields(value)...)
+	}
+	return vendor, flags, nil
+}
+
+// CPUCounts returns the number of CPUs of the host that are online and
+// present. OSX doesn't support CPU hotplug, so all present CPUs are
+// physically installed.
+func (h *Host) CPUCounts() (online int, present int, err error) {
+	active, err := syscall.SysctlUint32("hw.activecpu")
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	total, err := syscall.SysctlUint32("hw.ncpu")
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return int(active), int(total), nil
+}
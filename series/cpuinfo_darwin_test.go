@@ -0,0 +1,60 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type cpuInfoSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&cpuInfoSuite{})
+
+func fakeSysctl(values map[string]string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+		return "", errors.New("no such sysctl")
+	}
+}
+
+func (s *cpuInfoSuite) TestCPUInfoIntel(c *gc.C) {
+	s.PatchValue(series.Sysctl, fakeSysctl(map[string]string{
+		"machdep.cpu.vendor":         "GenuineIntel",
+		"machdep.cpu.features":       "FPU VME SSE4.1 SSE4.2 AES AVX1.0",
+		"machdep.cpu.leaf7_features": "AVX2 BMI1",
+	}))
+
+	vendor, flags, err := series.CPUInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(vendor, gc.Equals, "GenuineIntel")
+	c.Check(flags, jc.DeepEquals, []string{"fpu", "vme", "sse4_1", "sse4_2", "aes", "avx1_0", "avx2", "bmi1"})
+}
+
+func (s *cpuInfoSuite) TestCPUInfoAppleSilicon(c *gc.C) {
+	s.PatchValue(series.Sysctl, fakeSysctl(map[string]string{
+		"machdep.cpu.brand_string": "Apple M1",
+	}))
+
+	vendor, flags, err := series.CPUInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(vendor, gc.Equals, "Apple")
+	c.Check(flags, gc.HasLen, 0)
+}
+
+func (s *cpuInfoSuite) TestCPUInfoError(c *gc.C) {
+	s.PatchValue(series.Sysctl, fakeSysctl(nil))
+
+	_, _, err := series.CPUInfo()
+	c.Assert(err, gc.ErrorMatches, "no such sysctl")
+}
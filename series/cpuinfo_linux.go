@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// cpuInfoFile is the name of the file that is read in order to
+	// determine the CPU vendor and feature flags.
+	cpuInfoFile = "/proc/cpuinfo"
+
+	// cpuOnlineFile and cpuPresentFile are the names of the files that are
+	// read in order to determine the CPUs that are online and present.
+	cpuOnlineFile  = "/sys/devices/system/cpu/online"
+	cpuPresentFile = "/sys/devices/system/cpu/present"
+)
+
+func (h *Host) readCPUInfo() (string, []string, error) {
+	f, err := os.Open(h.path(cpuInfoFile))
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	vendor, flags, err := parseCPUInfo(f)
+	if err != nil {
+		return "", nil, errors.Annotatef(err, "reading %s", h.path(cpuInfoFile))
+	}
+	return vendor, flags, nil
+}
+
+// CPUCounts returns the number of CPUs of the host that are online and
+// present.
+func (h *Host) CPUCounts() (online int, present int, err error) {
+	if online, err = h.readCPURange(cpuOnlineFile); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if present, err = h.readCPURange(cpuPresentFile); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return online, present, nil
+}
+
+func (h *Host) readCPURange(file string) (int, error) {
+	contents, err := ioutil.ReadFile(h.path(file))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return parseCPURange(string(contents))
+}
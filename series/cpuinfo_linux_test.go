@@ -0,0 +1,132 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type cpuInfoSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&cpuInfoSuite{})
+
+const intelCPUInfo = `processor	: 0
+vendor_id	: GenuineIntel
+cpu family	: 6
+model		: 142
+model name	: Intel(R) Core(TM) i7-8650U CPU @ 1.90GHz
+flags		: fpu vme de pse tsc msr sse4_1 sse4_2 aes avx avx2
+
+processor	: 1
+vendor_id	: GenuineIntel
+flags		: fpu
+`
+
+const amdCPUInfo = `processor	: 0
+vendor_id	: AuthenticAMD
+cpu family	: 23
+model name	: AMD EPYC 7B12
+flags		: fpu vme de pse sse4_2 aes avx
+`
+
+const armCPUInfo = `processor	: 0
+BogoMIPS	: 50.00
+Features	: fp asimd evtstrm aes pmull sha1 sha2 crc32
+CPU implementer	: 0x41
+CPU architecture: 8
+`
+
+const ampereCPUInfo = `processor	: 0
+Features	: fp asimd aes
+CPU implementer	: 0xc0
+`
+
+func (s *cpuInfoSuite) TestCPUInfo(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		contents string
+		vendor   string
+		flags    []string
+	}{{
+		message:  "intel",
+		contents: intelCPUInfo,
+		vendor:   "GenuineIntel",
+		flags:    []string{"fpu", "vme", "de", "pse", "tsc", "msr", "sse4_1", "sse4_2", "aes", "avx", "avx2"},
+	}, {
+		message:  "amd",
+		contents: amdCPUInfo,
+		vendor:   "AuthenticAMD",
+		flags:    []string{"fpu", "vme", "de", "pse", "sse4_2", "aes", "avx"},
+	}, {
+		message:  "arm",
+		contents: armCPUInfo,
+		vendor:   "ARM",
+		flags:    []string{"fp", "asimd", "evtstrm", "aes", "pmull", "sha1", "sha2", "crc32"},
+	}, {
+		message:  "ampere",
+		contents: ampereCPUInfo,
+		vendor:   "Ampere",
+		flags:    []string{"fp", "asimd", "aes"},
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.CPUInfoFile, test.contents)
+
+		vendor, flags, err := series.CPUInfo()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(vendor, gc.Equals, test.vendor)
+		c.Check(flags, jc.DeepEquals, test.flags)
+	}
+}
+
+func (s *cpuInfoSuite) TestCPUInfoMissingFile(c *gc.C) {
+	s.PatchValue(series.CPUInfoFile, filepath.Join(c.MkDir(), "cpuinfo"))
+
+	_, _, err := series.CPUInfo()
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
+
+func (s *cpuInfoSuite) TestCPUCounts(c *gc.C) {
+	for i, test := range []struct {
+		online, present string
+		expectedOnline  int
+		expectedPresent int
+	}{
+		{"0\n", "0\n", 1, 1},
+		{"0-3\n", "0-3\n", 4, 4},
+		{"0-3,6\n", "0-7\n", 5, 8},
+		{"0,2,4-5\n", "0-5\n", 4, 6},
+	} {
+		c.Logf("%d: online %q present %q", i, test.online, test.present)
+		patchFile(c, &s.CleanupSuite, series.CPUOnlineFile, test.online)
+		patchFile(c, &s.CleanupSuite, series.CPUPresentFile, test.present)
+
+		online, present, err := series.CPUCounts()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(online, gc.Equals, test.expectedOnline)
+		c.Check(present, gc.Equals, test.expectedPresent)
+	}
+}
+
+func (s *cpuInfoSuite) TestCPUCountsBadRange(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.CPUOnlineFile, "3-0\n")
+	patchFile(c, &s.CleanupSuite, series.CPUPresentFile, "0-3\n")
+
+	_, _, err := series.CPUCounts()
+	c.Assert(err, gc.ErrorMatches, `cpu list "3-0" not valid`)
+}
+
+func (s *cpuInfoSuite) TestCPUCountsMissingFile(c *gc.C) {
+	s.PatchValue(series.CPUOnlineFile, filepath.Join(c.MkDir(), "online"))
+
+	_, _, err := series.CPUCounts()
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
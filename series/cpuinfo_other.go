@@ -0,0 +1,20 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+func (h *Host) readCPUInfo() (string, []string, error) {
+	return "", nil, errors.NotSupportedf("cpu info")
+}
+
+// CPUCounts is only supported on Linux and OSX.
+func (h *Host) CPUCounts() (online int, present int, err error) {
+	return 0, 0, errors.NotSupportedf("cpu counts")
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// Confidence describes how the series of a host was determined.
+type Confidence int
+
+const (
+	// Exact means the series was read from the host's own identification
+	// of its release, such as the ID and VERSION_ID of os-release.
+	Exact Confidence = iota
+
+	// Derived means the series was resolved from secondary release
+	// information, such as the redhat-release file or the host's
+	// distro-info data.
+	Derived
+
+	// Guessed means the series was resolved by a last-resort heuristic,
+	// such as the kernel release, and shouldn't be relied upon.
+	Guessed
+)
+
+// String returns the name of the confidence level.
+func (c Confidence) String() string {
+	switch c {
+	case Exact:
+		return "exact"
+	case Derived:
+		return "derived"
+	case Guessed:
+		return "guessed"
+	}
+	return "unknown"
+}
+
+// DetectSeries returns the series of the host, along with how confident the
+// detection is. Unlike HostSeries, the result is not cached.
+func DetectSeries() (string, Confidence, error) {
+	return defaultHost.DetectSeries()
+}
@@ -5,6 +5,7 @@ package series
 
 import (
 	"encoding/csv"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -20,6 +21,28 @@ var UbuntuDistroInfo = "/usr/share/distro-info/ubuntu.csv"
 
 const dateFormat = "2006-01-02"
 
+// distroInfoCacheEntry holds the parsed contents of a distro-info file, and
+// the modification time and size of the file when it was parsed.
+type distroInfoCacheEntry struct {
+	modTime time.Time
+	size    int64
+	info    map[string]DistroInfoSerie
+}
+
+var (
+	// distroInfoCache holds the parsed distro-info files, keyed on their
+	// path, so that they are only parsed again when they change.
+	distroInfoCacheMutex sync.Mutex
+	distroInfoCache      = make(map[string]distroInfoCacheEntry)
+)
+
+// resetDistroInfoCache discards the parsed distro-info files.
+func resetDistroInfoCache() {
+	distroInfoCacheMutex.Lock()
+	distroInfoCache = make(map[string]distroInfoCacheEntry)
+	distroInfoCacheMutex.Unlock()
+}
+
 // FileSystem defines a interface for interacting with the host os.
 type FileSystem interface {
 	Open(string) (*os.File, error)
@@ -34,6 +57,12 @@ type DistroInfoSerie struct {
 	Created  time.Time
 	Released time.Time
 	EOL      time.Time
+	// EOLServer is the end of life of the server edition of the series,
+	// which is zero if it is the same as EOL.
+	EOLServer time.Time
+	// EOLESM is the end of extended security maintenance for the series,
+	// which is zero for series without ESM.
+	EOLESM time.Time
 }
 
 // Supported returns true if the underlying series is supported or not.
@@ -42,6 +71,19 @@ func (d *DistroInfoSerie) Supported(now time.Time) bool {
 	return now.After(d.Released.UTC()) && now.Before(d.EOL.UTC())
 }
 
+// Development returns true if the underlying series has yet to be released.
+// It expects the time to be in UTC.
+func (d *DistroInfoSerie) Development(now time.Time) bool {
+	return now.Before(d.Released.UTC())
+}
+
+// ESM returns true if the underlying series is past its end of life, but
+// still within extended security maintenance. Series without an ESM end of
+// life are never in ESM. It expects the time to be in UTC.
+func (d *DistroInfoSerie) ESM(now time.Time) bool {
+	return !d.EOLESM.IsZero() && !now.Before(d.EOL.UTC()) && now.Before(d.EOLESM.UTC())
+}
+
 // LTS returns true if the series is an LTS or not.
 func (d *DistroInfoSerie) LTS() bool {
 	return strings.HasSuffix(d.Version, "LTS")
@@ -81,6 +123,22 @@ func (d *DistroInfo) Refresh() error {
 		_ = f.Close()
 	}()
 
+	// The file is only parsed again if it has changed since it was last
+	// parsed; the parsed info is never modified, so it can be shared.
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	distroInfoCacheMutex.Lock()
+	cached, ok := distroInfoCache[d.path]
+	distroInfoCacheMutex.Unlock()
+	if ok && cached.modTime.Equal(fileInfo.ModTime()) && cached.size == fileInfo.Size() {
+		d.mutex.Lock()
+		d.info = cached.info
+		d.mutex.Unlock()
+		return nil
+	}
+
 	csvReader := csv.NewReader(f)
 	csvReader.FieldsPerRecord = -1
 	records, err := csvReader.ReadAll()
@@ -100,16 +158,7 @@ func (d *DistroInfo) Refresh() error {
 		if !ok {
 			continue
 		}
-
-		createdDate, err := time.Parse(dateFormat, record.Created)
-		if err != nil {
-			continue
-		}
-		releasedDate, err := time.Parse(dateFormat, record.Released)
-		if err != nil {
-			continue
-		}
-		eolDate, err := time.Parse(dateFormat, record.EOL)
+		serie, err := parseRecord(record)
 		if err != nil {
 			continue
 		}
@@ -121,15 +170,16 @@ func (d *DistroInfo) Refresh() error {
 			foundPrecise = true
 		}
 
-		result[record.Series] = DistroInfoSerie{
-			Version:  record.Version,
-			CodeName: record.CodeName,
-			Series:   record.Series,
-			Created:  createdDate,
-			Released: releasedDate,
-			EOL:      eolDate,
-		}
+		result[record.Series] = serie
+	}
+
+	distroInfoCacheMutex.Lock()
+	distroInfoCache[d.path] = distroInfoCacheEntry{
+		modTime: fileInfo.ModTime(),
+		size:    fileInfo.Size(),
+		info:    result,
 	}
+	distroInfoCacheMutex.Unlock()
 
 	// Lock the distro info, as we're going to be updating it.
 	d.mutex.Lock()
@@ -139,6 +189,63 @@ func (d *DistroInfo) Refresh() error {
 	return nil
 }
 
+// requiredColumns are the columns that a distro-info file must have.
+var requiredColumns = []string{"version", "codename", "series", "created", "release", "eol"}
+
+// readDistroInfo reads the distro-info records from r. Unlike Refresh, which
+// skips any records it can't use, the columns are validated and a malformed
+// record is an error, as the source may be something other than the
+// distro-info package. As with Refresh, series prior to precise are ignored.
+func readDistroInfo(r io.Reader) (map[string]DistroInfoSerie, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading distro-info")
+	}
+	if len(records) == 0 {
+		return nil, errors.NotValidf("empty distro-info")
+	}
+
+	fieldNames := records[0]
+	for _, column := range requiredColumns {
+		var found bool
+		for _, name := range fieldNames {
+			if name == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.NotValidf("distro-info without %q column", column)
+		}
+	}
+
+	result := make(map[string]DistroInfoSerie)
+	var foundPrecise bool
+	for i, fields := range records[1:] {
+		// The header is line 1, so the records start at line 2.
+		line := i + 2
+		record, ok := consumeRecord(fieldNames, fields)
+		if !ok {
+			return nil, errors.NotValidf("distro-info line %d with missing fields", line)
+		}
+		serie, err := parseRecord(record)
+		if err != nil {
+			return nil, errors.Annotatef(err, "distro-info line %d", line)
+		}
+
+		if !foundPrecise {
+			if record.Series != "precise" {
+				continue
+			}
+			foundPrecise = true
+		}
+		result[record.Series] = serie
+	}
+	return result, nil
+}
+
 // SeriesInfo returns the DistroInfoSerie for the series name.
 func (d *DistroInfo) SeriesInfo(seriesName string) (DistroInfoSerie, bool) {
 	d.mutex.RLock()
@@ -150,12 +257,55 @@ func (d *DistroInfo) SeriesInfo(seriesName string) (DistroInfoSerie, bool) {
 
 // record defines a raw distro line that hasn't been parsed.
 type record struct {
-	Version  string
-	CodeName string
-	Series   string
-	Created  string
-	Released string
-	EOL      string
+	Version   string
+	CodeName  string
+	Series    string
+	Created   string
+	Released  string
+	EOL       string
+	EOLServer string
+	EOLESM    string
+}
+
+// parseRecord parses the dates of a record into a DistroInfoSerie.
+func parseRecord(record record) (DistroInfoSerie, error) {
+	createdDate, err := time.Parse(dateFormat, record.Created)
+	if err != nil {
+		return DistroInfoSerie{}, errors.Trace(err)
+	}
+	releasedDate, err := time.Parse(dateFormat, record.Released)
+	if err != nil {
+		return DistroInfoSerie{}, errors.Trace(err)
+	}
+	eolDate, err := time.Parse(dateFormat, record.EOL)
+	if err != nil {
+		return DistroInfoSerie{}, errors.Trace(err)
+	}
+
+	// Only older series have a separate server date, and only LTS
+	// series have an ESM date.
+	var eolServerDate, eolESMDate time.Time
+	if record.EOLServer != "" {
+		if eolServerDate, err = time.Parse(dateFormat, record.EOLServer); err != nil {
+			return DistroInfoSerie{}, errors.Trace(err)
+		}
+	}
+	if record.EOLESM != "" {
+		if eolESMDate, err = time.Parse(dateFormat, record.EOLESM); err != nil {
+			return DistroInfoSerie{}, errors.Trace(err)
+		}
+	}
+
+	return DistroInfoSerie{
+		Version:   record.Version,
+		CodeName:  record.CodeName,
+		Series:    record.Series,
+		Created:   createdDate,
+		Released:  releasedDate,
+		EOL:       eolDate,
+		EOLServer: eolServerDate,
+		EOLESM:    eolESMDate,
+	}, nil
 }
 
 func consumeRecord(headers []string, fields []string) (record, bool) {
@@ -166,10 +316,6 @@ func consumeRecord(headers []string, fields []string) (record, bool) {
 			break
 		}
 
-		if field == "" {
-			malformed = true
-		}
-
 		switch headers[i] {
 		case "version":
 			result.Version = field
@@ -183,9 +329,22 @@ func consumeRecord(headers []string, fields []string) (record, bool) {
 			result.Released = field
 		case "eol":
 			result.EOL = field
+		case "eol-server":
+			result.EOLServer = field
+		case "eol-esm":
+			result.EOLESM = field
 		}
 	}
 
-	// If the record is malformed then the validity of the record is not ok.
+	// The record is malformed if any of the required fields are empty, the
+	// optional eol-server and eol-esm fields may be left empty.
+	for _, field := range []string{
+		result.Version, result.CodeName, result.Series,
+		result.Created, result.Released, result.EOL,
+	} {
+		if field == "" {
+			malformed = true
+		}
+	}
 	return result, !malformed
 }
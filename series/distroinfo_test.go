@@ -6,6 +6,7 @@ package series
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -88,6 +89,76 @@ func (s *DistroInfoSuite) TestRefresh(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func (s *DistroInfoSuite) TestRefreshCached(c *gc.C) {
+	resetDistroInfoCache()
+	defer resetDistroInfoCache()
+
+	path := filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(path, []byte(distroInfoContents), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = NewDistroInfo(path).Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Replace the parsed info, to show that it is reused while the file
+	// is unchanged.
+	entry := distroInfoCache[path]
+	entry.info = map[string]DistroInfoSerie{"cached": {Series: "cached"}}
+	distroInfoCache[path] = entry
+
+	info := NewDistroInfo(path)
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := info.SeriesInfo("cached")
+	c.Assert(ok, jc.IsTrue)
+
+	err = ioutil.WriteFile(path, []byte(distroInfoContents+"99.10,Star Trek,kirk,2019-10-17,2020-04-23,2366-01-17\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = info.SeriesInfo("cached")
+	c.Assert(ok, jc.IsFalse)
+	_, ok = info.SeriesInfo("kirk")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *DistroInfoSuite) TestRefreshOptionalDates(c *gc.C) {
+	resetDistroInfoCache()
+	defer resetDistroInfoCache()
+
+	path := filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(path, []byte(`version,codename,series,created,release,eol,eol-server,eol-esm
+12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26,2017-04-28,2019-04-26
+18.04 LTS,Bionic Beaver,bionic,2017-10-19,2018-04-26,2023-05-31,,2028-04-26
+18.10,Cosmic Cuttlefish,cosmic,2018-04-26,2018-10-18,2019-07-18,,
+19.04,Disco Dingo,disco,2018-10-18,2019-04-18,,,
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	info := NewDistroInfo(path)
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	precise, ok := info.SeriesInfo("precise")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(precise.EOLServer, gc.Equals, time.Date(2017, 4, 28, 0, 0, 0, 0, time.UTC))
+	c.Check(precise.EOLESM, gc.Equals, time.Date(2019, 4, 26, 0, 0, 0, 0, time.UTC))
+
+	bionic, ok := info.SeriesInfo("bionic")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(bionic.EOLServer.IsZero(), jc.IsTrue)
+	c.Check(bionic.EOLESM, gc.Equals, time.Date(2028, 4, 26, 0, 0, 0, 0, time.UTC))
+
+	cosmic, ok := info.SeriesInfo("cosmic")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cosmic.EOL, gc.Equals, time.Date(2019, 7, 18, 0, 0, 0, 0, time.UTC))
+	c.Check(cosmic.EOLServer.IsZero(), jc.IsTrue)
+	c.Check(cosmic.EOLESM.IsZero(), jc.IsTrue)
+
+	// The eol date is required.
+	_, ok = info.SeriesInfo("disco")
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *DistroInfoSuite) TestDistroInfoSerieSupported(c *gc.C) {
 	now := s.fixedTime
 
@@ -134,6 +205,33 @@ func (s *DistroInfoSuite) TestDistroInfoSerieSupported(c *gc.C) {
 	}
 }
 
+func (s *DistroInfoSuite) TestDistroInfoSerieDevelopment(c *gc.C) {
+	now := s.fixedTime
+
+	serie := &DistroInfoSerie{
+		Released: now.AddDate(0, 0, 1),
+		EOL:      now.AddDate(1, 0, 0),
+	}
+	c.Assert(serie.Development(now), jc.IsTrue)
+	c.Assert(serie.Development(now.AddDate(0, 0, 2)), jc.IsFalse)
+}
+
+func (s *DistroInfoSuite) TestDistroInfoSerieESM(c *gc.C) {
+	now := s.fixedTime
+
+	serie := &DistroInfoSerie{
+		Released: now.AddDate(-5, 0, 0),
+		EOL:      now.AddDate(0, 0, -1),
+		EOLESM:   now.AddDate(0, 0, 1),
+	}
+	c.Assert(serie.ESM(now), jc.IsTrue)
+	c.Assert(serie.ESM(now.AddDate(0, 0, -2)), jc.IsFalse)
+	c.Assert(serie.ESM(now.AddDate(0, 0, 2)), jc.IsFalse)
+
+	serie.EOLESM = time.Time{}
+	c.Assert(serie.ESM(now), jc.IsFalse)
+}
+
 func (s *DistroInfoSuite) TestDistroInfoSerieLTS(c *gc.C) {
 	tests := []struct {
 		Name     string
@@ -0,0 +1,40 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os/v2"
+)
+
+var (
+	// getenv looks up an environment variable (overrideable for testing).
+	getenv = os.Getenv
+)
+
+// DefaultEditor returns the editor to open for interactive editing. The
+// $EDITOR and $VISUAL environment variables are honoured, in that order;
+// otherwise nano is used on Ubuntu, where it is installed by default, and vi
+// everywhere else.
+func DefaultEditor() (string, error) {
+	for _, name := range []string{"EDITOR", "VISUAL"} {
+		if editor := getenv(name); editor != "" {
+			return editor, nil
+		}
+	}
+	hostSeries, err := HostSeries()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	osType, err := GetOSFromSeries(hostSeries)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if osType == jujuos.Ubuntu {
+		return "nano", nil
+	}
+	return "vi", nil
+}
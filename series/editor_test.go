@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type editorSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&editorSuite{})
+
+func (s *editorSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	// Looking up an unknown series reads distro-info; ensure nothing is
+	// read from the test machine.
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+}
+
+func (s *editorSuite) patchEnv(env map[string]string) {
+	s.PatchValue(series.Getenv, func(name string) string {
+		return env[name]
+	})
+}
+
+func (s *editorSuite) TestDefaultEditor(c *gc.C) {
+	for i, test := range []struct {
+		series   string
+		env      map[string]string
+		expected string
+	}{
+		{"jammy", nil, "nano"},
+		{"centos7", nil, "vi"},
+		{"jammy", map[string]string{"EDITOR": "emacs"}, "emacs"},
+		{"centos7", map[string]string{"VISUAL": "vim"}, "vim"},
+		{"centos7", map[string]string{"EDITOR": "emacs", "VISUAL": "vim"}, "emacs"},
+	} {
+		c.Logf("%d: %s %v", i, test.series, test.env)
+		hostSeries := test.series
+		s.PatchValue(&series.HostSeries, func() (string, error) {
+			return hostSeries, nil
+		})
+		s.patchEnv(test.env)
+
+		editor, err := series.DefaultEditor()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(editor, gc.Equals, test.expected)
+	}
+}
+
+func (s *editorSuite) TestDefaultEditorUnknownSeries(c *gc.C) {
+	s.PatchValue(&series.HostSeries, func() (string, error) {
+		return "unknown", nil
+	})
+	s.patchEnv(nil)
+
+	_, err := series.DefaultEditor()
+	c.Assert(err, gc.ErrorMatches, `unknown OS for series: "unknown"`)
+}
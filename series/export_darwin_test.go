@@ -0,0 +1,8 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+var (
+	Sysctl = &sysctl
+)
@@ -4,18 +4,50 @@
 package series
 
 var (
-	UbuntuDistroInfoPath = &UbuntuDistroInfo
-	ReadSeries           = readSeries
-	OSReleaseFile        = &osReleaseFile
+	ReadSeries            = readSeries
+	OSReleaseFile         = &osReleaseFile
+	CPUInfoFile           = &cpuInfoFile
+	RedhatReleaseFile     = &redhatReleaseFile
+	MountsFile            = &mountsFile
+	InitCommFile          = &initCommFile
+	CgroupRoot            = &cgroupRoot
+	KernelReleaseFile     = &kernelReleaseFile
+	KallsymsFile          = &kallsymsFile
+	FilesystemsFile       = &filesystemsFile
+	PIDMaxFile            = &pidMaxFile
+	KernelBuildFile       = &kernelBuildFile
+	KernelRealtimeFile    = &kernelRealtimeFile
+	HugePagesDir          = &hugePagesDir
+	IPForwardFile         = &ipForwardFile
+	KernelCmdlineFile     = &kernelCmdlineFile
+	SysBlockDir           = &sysBlockDir
+	CPUOnlineFile         = &cpuOnlineFile
+	CPUPresentFile        = &cpuPresentFile
+	UsernsCloneFile       = &usernsCloneFile
+	MaxUserNamespacesFile = &maxUserNamespacesFile
+	AppArmorProfilesFile  = &appArmorProfilesFile
+	MaxMapCountFile       = &maxMapCountFile
+	FIPSEnabledFile       = &fipsEnabledFile
+	SeccompDir            = &seccompDir
+	KernelConfigDir       = &kernelConfigDir
+	UsrLibOSReleaseFile   = &usrLibOSReleaseFile
+	LSBReleaseFile        = &lsbReleaseFile
+	ProcVersionFile       = &procVersionFile
+	ContainerEnvFiles     = &containerEnvFiles
+	InitEnvironFile       = &initEnvironFile
+	InitCgroupFile        = &initCgroupFile
 )
 
 // HideUbuntuSeries hides the global state of the ubuntu series for tests. The
 // function returns a closure, that puts the global state back once called.
-// This is not concurrent safe.
 func HideUbuntuSeries() func() {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
 	origSeries := ubuntuSeries
 	ubuntuSeries = make(map[string]SeriesVersionInfo)
 	return func() {
+		seriesVersionsMutex.Lock()
+		defer seriesVersionsMutex.Unlock()
 		ubuntuSeries = origSeries
 	}
 }
@@ -5,25 +5,84 @@ package series
 
 var (
 	KernelToMajor                 = kernelToMajor
+	KernelToMajorMinor            = kernelToMajorMinor
+	ParseKernelRelease            = parseKernelRelease
+	ParseReleaseValues            = parseReleaseValues
 	MacOSXSeriesFromKernelVersion = macOSXSeriesFromKernelVersion
 	MacOSXSeriesFromMajorVersion  = macOSXSeriesFromMajorVersion
+	MacOSXSeriesFromUname         = macOSXSeriesFromUname
 	TimeNow                       = &timeNow
+	ReadHostSeries                = &readHostSeries
+	UbuntuDistroInfoPath          = &UbuntuDistroInfo
+	HostsFile                     = &hostsFile
+	HostnameFile                  = &hostnameFile
+	SSHDBinary                    = &sshdBinary
+	SSHDConfigFile                = &sshdConfigFile
+	OSHostname                    = &osHostname
+	LookupHost                    = &lookupHost
+	LookupAddr                    = &lookupAddr
+	BinDir                        = &binDir
+	UbuntuProStatusFile           = &ubuntuProStatusFile
+	Getenv                        = &getenv
+	ContainerSockets              = &containerSockets
+	ResolvConfFile                = &resolvConfFile
+	JujuAgentsDir                 = &jujuAgentsDir
+	JujuToolsDir                  = &jujuToolsDir
+	SystemdSystemDir              = &systemdSystemDir
+	NetworkManagerConfigFile      = &networkManagerConfigFile
+	NetworkManagerConfigDir       = &networkManagerConfigDir
+	GOARCH                        = &goarch
 )
 
 func SetSeriesVersions(value map[string]string) func() {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
 	origVersions := seriesVersions
 	origUpdated := updatedseriesVersions
+	resetDistroInfoCache()
 	seriesVersions = value
 	updateVersionSeries()
 	updatedseriesVersions = len(value) != 0
 	return func() {
+		seriesVersionsMutex.Lock()
+		defer seriesVersionsMutex.Unlock()
+		resetDistroInfoCache()
 		seriesVersions = origVersions
 		updateVersionSeries()
 		updatedseriesVersions = origUpdated
 	}
 }
 
-// UbuntuSupportedSeries exports the ubuntuSeries for testing.
+// pristineUbuntuSeries holds the compiled-in ubuntuSeries, before any
+// updates from distro-info.
+var pristineUbuntuSeries = copyUbuntuSeries(ubuntuSeries)
+
+func copyUbuntuSeries(from map[string]SeriesVersionInfo) map[string]SeriesVersionInfo {
+	result := make(map[string]SeriesVersionInfo, len(from))
+	for name, info := range from {
+		result[name] = info
+	}
+	return result
+}
+
+// ResetUbuntuSeries resets the ubuntuSeries to the compiled-in values,
+// undoing any updates from distro-info made by earlier tests. The function
+// returns a closure, that puts the previous state back once called.
+func ResetUbuntuSeries() func() {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	origSeries := ubuntuSeries
+	ubuntuSeries = copyUbuntuSeries(pristineUbuntuSeries)
+	return func() {
+		seriesVersionsMutex.Lock()
+		defer seriesVersionsMutex.Unlock()
+		ubuntuSeries = origSeries
+	}
+}
+
+// UbuntuSupportedSeries exports a copy of the ubuntuSeries for testing.
 func UbuntuSupportedSeries() map[string]SeriesVersionInfo {
-	return ubuntuSeries
+	seriesVersionsMutex.RLock()
+	defer seriesVersionsMutex.RUnlock()
+	return copyUbuntuSeries(ubuntuSeries)
 }
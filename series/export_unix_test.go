@@ -0,0 +1,11 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package series
+
+var (
+	Getrlimit = &getrlimit
+)
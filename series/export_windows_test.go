@@ -7,6 +7,7 @@ package series
 var (
 	CurrentVersionKey = &currentVersionKey
 	IsNanoKey         = &isNanoKey
+	RtlGetVersion     = &rtlGetVersion
 	ReadSeries        = readSeries
 	WindowsVersionMap = windowsVersions
 	WindowsNanoMap    = windowsNanoVersions
@@ -0,0 +1,73 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// Host provides introspection of a machine. The package level detection
+// functions delegate to a default Host, which inspects the machine the
+// current process is running on.
+//
+// Some detection can only describe the current process, and has no Host
+// method: ResourceLimits reads the limits of the process itself, and
+// DefaultEditor reads its environment. Series and CPUInfo ignore Root on
+// OSX and Windows, where they are read from the running kernel.
+type Host struct {
+	// Root, if set, is prepended to every file that is read on Linux, so
+	// that a mounted image or a tree of fixture files can be inspected.
+	Root string
+
+	// Runner, if set, is used to run commands instead of the package
+	// CommandRunner.
+	Runner CommandRunner
+}
+
+// defaultHost is the Host used by the package level detection functions.
+var defaultHost = NewHost()
+
+// NewHost returns a Host that inspects the machine the current process is
+// running on. Its Root and Runner can be set to inspect another machine.
+func NewHost() *Host {
+	return &Host{}
+}
+
+// path returns the location of the host file at p.
+func (h *Host) path(p string) string {
+	if h.Root == "" {
+		return p
+	}
+	return filepath.Join(h.Root, p)
+}
+
+// runner returns the CommandRunner used to run commands on the host.
+func (h *Host) runner() CommandRunner {
+	if h.Runner != nil {
+		return h.Runner
+	}
+	return commandRunner
+}
+
+// Series returns the series of the host. Unlike HostSeries, the result is
+// not cached.
+func (h *Host) Series() (string, error) {
+	series, err := h.readSeries()
+	if err != nil {
+		return series, errors.Annotate(err, "cannot determine host series")
+	}
+	return series, nil
+}
+
+// OS returns the operating system of the host.
+func (h *Host) OS() (os.OSType, error) {
+	series, err := h.Series()
+	if err != nil {
+		return os.Unknown, errors.Trace(err)
+	}
+	return GetOSFromSeries(series)
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+func (s *hostSuite) TestReleaseVersion(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/redhat-release": "Rocky Linux release 9.2 (Blue Onyx)\n",
+	})
+	h := &series.Host{Root: root}
+
+	version, err := h.ReleaseVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "9")
+}
+
+func (s *hostSuite) TestHostSeries(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/os-release": "ID=ubuntu\nVERSION_ID=\"22.04\"\n",
+	})
+	h := &series.Host{Root: root}
+
+	hostSeries, err := h.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "jammy")
+
+	osType, err := h.OS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, os.Ubuntu)
+}
+
+func (s *hostSuite) TestHostSeriesFromHostDistroInfo(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/os-release":            "ID=ubuntu\nVERSION_ID=\"95.04\"\n",
+		*series.UbuntuDistroInfoPath: "version,codename,series,created,release,eol\n12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26\n95.04,Zesty Zebra,zebra,2094-10-17,2095-04-17,2096-01-17\n",
+	})
+	h := &series.Host{Root: root}
+
+	hostSeries, err := h.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "zebra")
+
+	// The package's series versions describe the local machine, so the
+	// host's distro-info must not leak into them.
+	_, ok := series.UbuntuSupportedSeries()["zebra"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *hostSuite) TestHostSeriesRedhatRelease(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/redhat-release": "CentOS Linux release 7.9.2009 (Core)\n",
+	})
+	h := &series.Host{Root: root}
+
+	osType, err := h.OS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, os.CentOS)
+}
+
+func (s *hostSuite) TestHostSeriesError(c *gc.C) {
+	h := &series.Host{Root: c.MkDir()}
+
+	hostSeries, err := h.Series()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: .*no such file or directory")
+	c.Check(hostSeries, gc.Equals, "unknown")
+}
+
+func (s *hostSuite) TestHostWithRootKernelProbes(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/proc/mounts":                              "/dev/sda1 / xfs rw,relatime 0 0\n",
+		"/proc/1/comm":                              "systemd\n",
+		"/proc/sys/kernel/osrelease":                "5.15.0-91-generic\n",
+		"/proc/filesystems":                         "nodev\toverlay\n",
+		"/sys/fs/cgroup/cgroup.controllers":         "memory pids\n",
+		"/sys/fs/cgroup/init.scope/memory.swap.max": "max\n",
+	})
+	h := &series.Host{Root: root}
+
+	fsType, err := h.RootFSType()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fsType, gc.Equals, "xfs")
+
+	pid1, err := h.SystemdIsPID1()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pid1, jc.IsTrue)
+
+	swap, err := h.SwapAccountingEnabled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(swap, jc.IsTrue)
+
+	ioURing, err := h.IOUringAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ioURing, jc.IsTrue)
+
+	overlay, err := h.OverlayFSAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(overlay, jc.IsTrue)
+}
+
+func (s *hostSuite) TestHostWithEmptyRootKernelProbes(c *gc.C) {
+	h := &series.Host{Root: c.MkDir()}
+
+	_, err := h.RootFSType()
+	c.Assert(err, gc.NotNil)
+	_, err = h.SystemdIsPID1()
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *hostSuite) TestHostDocsURL(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/os-release": "ID=ubuntu\nVERSION_ID=\"22.04\"\nHOME_URL=\"https://example.ubuntu.com/\"\n",
+	})
+	h := &series.Host{Root: root}
+
+	c.Check(h.DocsURL(os.Ubuntu), gc.Equals, "https://example.ubuntu.com/")
+	// The host's HOME_URL only describes the OS it is running.
+	c.Check(h.DocsURL(os.CentOS), gc.Equals, "https://www.centos.org/")
+}
+
+func (s *hostSuite) TestHostDocsURLNoHomeURL(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/os-release": "ID=ubuntu\nVERSION_ID=\"22.04\"\n",
+	})
+	h := &series.Host{Root: root}
+
+	c.Check(h.DocsURL(os.Ubuntu), gc.Equals, "https://www.ubuntu.com/")
+}
+
+func (s *hostSuite) TestHostDetectSeries(c *gc.C) {
+	for i, test := range []struct {
+		message    string
+		files      map[string]string
+		series     string
+		confidence series.Confidence
+	}{{
+		message:    "os-release",
+		files:      map[string]string{"/etc/os-release": "ID=ubuntu\nVERSION_ID=\"22.04\"\n"},
+		series:     "jammy",
+		confidence: series.Exact,
+	}, {
+		message:    "unknown os-release ID",
+		files:      map[string]string{"/etc/os-release": "ID=gentoo\nVERSION_ID=\"2.14\"\n"},
+		series:     "genericlinux",
+		confidence: series.Guessed,
+	}, {
+		message:    "os-release ID_LIKE",
+		files:      map[string]string{"/etc/os-release": "ID=raspbian\nID_LIKE=debian\nVERSION_ID=\"12\"\n"},
+		series:     "debian12",
+		confidence: series.Derived,
+	}, {
+		message:    "lsb-release",
+		files:      map[string]string{"/etc/lsb-release": "DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=14.04\nDISTRIB_CODENAME=trusty\n"},
+		series:     "trusty",
+		confidence: series.Derived,
+	}, {
+		message:    "redhat-release",
+		files:      map[string]string{"/etc/redhat-release": "CentOS Linux release 7.9.2009 (Core)\n"},
+		series:     "centos7",
+		confidence: series.Derived,
+	}, {
+		message:    "kernel release",
+		files:      map[string]string{"/proc/sys/kernel/osrelease": "3.10.0-1160.el7.x86_64\n"},
+		series:     "centos7",
+		confidence: series.Guessed,
+	}, {
+		message:    "generic kernel release",
+		files:      map[string]string{"/proc/sys/kernel/osrelease": "6.6.8-gentoo\n"},
+		series:     "genericlinux",
+		confidence: series.Guessed,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		h := &series.Host{Root: makeHostRoot(c, test.files)}
+
+		hostSeries, confidence, err := h.DetectSeries()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(hostSeries, gc.Equals, test.series)
+		c.Check(confidence, gc.Equals, test.confidence)
+	}
+}
+
+func (s *hostSuite) TestHostDetectSeriesFromHostDistroInfo(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/os-release":            "ID=ubuntu\nVERSION_ID=\"95.04\"\n",
+		*series.UbuntuDistroInfoPath: "version,codename,series,created,release,eol\n12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26\n95.04,Zesty Zebra,zebra,2094-10-17,2095-04-17,2096-01-17\n",
+	})
+	h := &series.Host{Root: root}
+
+	hostSeries, confidence, err := h.DetectSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "zebra")
+	c.Check(confidence, gc.Equals, series.Derived)
+}
+
+func (s *hostSuite) TestHostDetectSeriesError(c *gc.C) {
+	h := &series.Host{Root: c.MkDir()}
+
+	hostSeries, _, err := h.DetectSeries()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: .*")
+	c.Check(hostSeries, gc.Equals, "unknown")
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type hostSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&hostSuite{})
+
+func (s *hostSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	// Ensure nothing is read from the distro-info of the test machine.
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+}
+
+// makeHostRoot creates a tree of fixture files, keyed on their absolute
+// path on the host, and returns its root.
+func makeHostRoot(c *gc.C, files map[string]string) string {
+	root := c.MkDir()
+	for path, contents := range files {
+		path = filepath.Join(root, path)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(path, []byte(contents), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return root
+}
+
+// patchFile writes contents to a fixture file and patches the variable
+// holding the location of the host file to point at it.
+func patchFile(c *gc.C, s *testing.CleanupSuite, variable *string, contents string) {
+	filename := filepath.Join(c.MkDir(), filepath.Base(*variable))
+	err := ioutil.WriteFile(filename, []byte(contents), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(variable, filename)
+}
+
+func (s *hostSuite) TestHostWithRoot(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"/etc/hosts":                            "127.0.0.1 localhost\n127.0.1.1 juju-machine-0\n",
+		"/etc/hostname":                         "juju-machine-0.example.com\n",
+		"/var/lib/ubuntu-advantage/status.json": `{"attached": true}`,
+	})
+	h := series.NewHost()
+	h.Root = root
+
+	found, err := h.HasUbuntuHostsEntry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found, jc.IsTrue)
+
+	short, fqdn, err := h.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(short, gc.Equals, "juju-machine-0")
+	c.Check(fqdn, gc.Equals, "juju-machine-0.example.com")
+
+	attached, err := h.UbuntuProAttached()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attached, jc.IsTrue)
+}
+
+func (s *hostSuite) TestHostWithEmptyRoot(c *gc.C) {
+	h := &series.Host{Root: c.MkDir()}
+
+	attached, err := h.UbuntuProAttached()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attached, jc.IsFalse)
+}
+
+func (s *hostSuite) TestHostnameWithRootSkipsLocalLookups(c *gc.C) {
+	s.PatchValue(series.OSHostname, func() (string, error) {
+		c.Fatalf("unexpected call to os.Hostname")
+		return "", nil
+	})
+	s.PatchValue(series.LookupHost, func(string) ([]string, error) {
+		c.Fatalf("unexpected host lookup")
+		return nil, nil
+	})
+	root := makeHostRoot(c, map[string]string{
+		"/etc/hostname": "juju-machine-0\n",
+	})
+	h := &series.Host{Root: root}
+
+	short, fqdn, err := h.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(short, gc.Equals, "juju-machine-0")
+	c.Check(fqdn, gc.Equals, "juju-machine-0")
+
+	h.Root = c.MkDir()
+	_, _, err = h.Hostname()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,11 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// SystemdIsPID1 returns true if systemd is running as PID 1. Inside some
+// containers systemd may be installed without being the init process, in
+// which case its services can't be managed.
+func SystemdIsPID1() (bool, error) {
+	return defaultHost.SystemdIsPID1()
+}
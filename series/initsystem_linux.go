@@ -0,0 +1,26 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// initCommFile is the name of the file that is read in order to
+	// determine the command name of PID 1 (overrideable for testing).
+	initCommFile = "/proc/1/comm"
+)
+
+// SystemdIsPID1 returns true if systemd is running as PID 1 on the host.
+func (h *Host) SystemdIsPID1() (bool, error) {
+	contents, err := ioutil.ReadFile(h.path(initCommFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return strings.TrimSpace(string(contents)) == "systemd", nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type initSystemSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&initSystemSuite{})
+
+func (s *initSystemSuite) TestSystemdIsPID1(c *gc.C) {
+	for i, test := range []struct {
+		comm     string
+		expected bool
+	}{
+		{"systemd\n", true},
+		{"bash\n", false},
+		{"tini\n", false},
+		{"init\n", false},
+	} {
+		c.Logf("%d: %q", i, test.comm)
+		patchFile(c, &s.CleanupSuite, series.InitCommFile, test.comm)
+
+		pid1, err := series.SystemdIsPID1()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(pid1, gc.Equals, test.expected)
+	}
+}
+
+func (s *initSystemSuite) TestSystemdIsPID1MissingFile(c *gc.C) {
+	s.PatchValue(series.InitCommFile, filepath.Join(c.MkDir(), "comm"))
+
+	_, err := series.SystemdIsPID1()
+	c.Assert(err, gc.NotNil)
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// jujuAgentsDir and jujuToolsDir are the directories that are read in
+	// order to determine whether the host runs a Juju agent (overrideable
+	// for testing).
+	jujuAgentsDir = "/var/lib/juju/agents"
+	jujuToolsDir  = "/var/lib/juju/tools"
+
+	// systemdSystemDir is the directory that is read in order to find the
+	// installed jujud services (overrideable for testing).
+	systemdSystemDir = "/etc/systemd/system"
+)
+
+// IsJujuManaged returns true if the machine the current process is running
+// on is managed by Juju: it has an agent configured, and either the jujud
+// binary or a jujud service installed.
+func IsJujuManaged() (bool, error) {
+	return defaultHost.IsJujuManaged()
+}
+
+// IsJujuManaged returns true if the host is managed by Juju.
+func (h *Host) IsJujuManaged() (bool, error) {
+	agents, err := ioutil.ReadDir(h.path(jujuAgentsDir))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(agents) == 0 {
+		return false, nil
+	}
+
+	// Each agent binary version is unpacked into its own tools directory,
+	// such as "3.5.1-ubuntu-amd64".
+	binaries, err := filepath.Glob(filepath.Join(h.path(jujuToolsDir), "*", "jujud"))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(binaries) > 0 {
+		return true, nil
+	}
+
+	units, err := ioutil.ReadDir(h.path(systemdSystemDir))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, unit := range units {
+		if strings.HasPrefix(unit.Name(), "jujud-") && strings.HasSuffix(unit.Name(), ".service") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type jujuSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&jujuSuite{})
+
+func (s *jujuSuite) TestIsJujuManaged(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		files    map[string]string
+		expected bool
+	}{{
+		message: "machine agent with binary",
+		files: map[string]string{
+			"/var/lib/juju/agents/machine-0/agent.conf":    "tag: machine-0\n",
+			"/var/lib/juju/tools/3.5.1-ubuntu-amd64/jujud": "",
+		},
+		expected: true,
+	}, {
+		message: "machine agent with service",
+		files: map[string]string{
+			"/var/lib/juju/agents/machine-0/agent.conf":   "tag: machine-0\n",
+			"/etc/systemd/system/jujud-machine-0.service": "[Service]\n",
+		},
+		expected: true,
+	}, {
+		message: "agent without binary or service",
+		files: map[string]string{
+			"/var/lib/juju/agents/machine-0/agent.conf": "tag: machine-0\n",
+			"/etc/systemd/system/ssh.service":           "[Service]\n",
+		},
+		expected: false,
+	}, {
+		message: "binary without agent",
+		files: map[string]string{
+			"/var/lib/juju/tools/3.5.1-ubuntu-amd64/jujud": "",
+		},
+		expected: false,
+	}, {
+		message:  "unmanaged",
+		files:    map[string]string{"/etc/hostname": "ubuntu\n"},
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		h := &series.Host{Root: makeHostRoot(c, test.files)}
+
+		managed, err := h.IsJujuManaged()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(managed, gc.Equals, test.expected)
+	}
+}
+
+func (s *jujuSuite) TestIsJujuManagedOverride(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"agents/unit-mysql-0/agent.conf": "tag: unit-mysql-0\n",
+		"system/jujud-machine-1.service": "[Service]\n",
+	})
+	s.PatchValue(series.JujuAgentsDir, filepath.Join(root, "agents"))
+	s.PatchValue(series.JujuToolsDir, filepath.Join(root, "tools"))
+	s.PatchValue(series.SystemdSystemDir, filepath.Join(root, "system"))
+
+	managed, err := series.IsJujuManaged()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(managed, jc.IsTrue)
+}
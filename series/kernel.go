@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// HostKernelVersion returns the major and minor version of the host's
+// kernel, such as 5 and 15 for "5.15.0-91-generic".
+func HostKernelVersion() (major, minor int, err error) {
+	return defaultHost.KernelVersion()
+}
+
+// IOUringAvailable returns true if the host kernel supports io_uring, which
+// was added in Linux 5.1.
+func IOUringAvailable() (bool, error) {
+	return defaultHost.IOUringAvailable()
+}
+
+// OverlayFSAvailable returns true if the host kernel supports the overlay
+// filesystem.
+func OverlayFSAvailable() (bool, error) {
+	return defaultHost.OverlayFSAvailable()
+}
+
+// PIDMax returns the maximum process ID of the host, as configured by the
+// kernel.pid_max sysctl.
+func PIDMax() (int, error) {
+	return defaultHost.PIDMax()
+}
+
+// IsRealtimeKernel returns true if the host is running a real-time
+// (PREEMPT_RT) kernel.
+func IsRealtimeKernel() (bool, error) {
+	return defaultHost.IsRealtimeKernel()
+}
+
+// HugePageSizes returns the huge page sizes supported by the host kernel,
+// such as "2048kB" and "1048576kB", smallest first.
+func HugePageSizes() ([]string, error) {
+	return defaultHost.HugePageSizes()
+}
+
+// UnprivilegedUserNSEnabled returns true if unprivileged users of the host
+// can create user namespaces, as rootless containers require. Debian's
+// kernel.unprivileged_userns_clone sysctl and user.max_user_namespaces are
+// both consulted.
+func UnprivilegedUserNSEnabled() (bool, error) {
+	return defaultHost.UnprivilegedUserNSEnabled()
+}
+
+// VMMaxMapCount returns the maximum number of memory map areas a process may
+// have, as configured by the vm.max_map_count sysctl. Workloads such as
+// Elasticsearch require it to be raised from the default of 65530.
+func VMMaxMapCount() (int, error) {
+	return defaultHost.VMMaxMapCount()
+}
+
+// FIPSEnabled returns true if the host kernel is running in FIPS mode, as
+// reported by the crypto.fips_enabled sysctl.
+func FIPSEnabled() (bool, error) {
+	return defaultHost.FIPSEnabled()
+}
+
+// SeccompAvailable returns true if the host kernel supports seccomp system
+// call filtering, as sandboxes require.
+func SeccompAvailable() (bool, error) {
+	return defaultHost.SeccompAvailable()
+}
@@ -0,0 +1,299 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// kernelReleaseFile is the name of the file that is read in order to
+	// determine the kernel release (overrideable for testing).
+	kernelReleaseFile = "/proc/sys/kernel/osrelease"
+
+	// kallsymsFile is the name of the file that is read in order to
+	// determine the symbols exported by the kernel (overrideable for
+	// testing).
+	kallsymsFile = "/proc/kallsyms"
+
+	// filesystemsFile is the name of the file that is read in order to
+	// determine the filesystems supported by the kernel (overrideable for
+	// testing).
+	filesystemsFile = "/proc/filesystems"
+
+	// pidMaxFile is the name of the file that is read in order to
+	// determine the maximum process ID (overrideable for testing).
+	pidMaxFile = "/proc/sys/kernel/pid_max"
+
+	// kernelBuildFile is the name of the file that is read in order to
+	// determine how the kernel was built; it holds the same value as
+	// "uname -v" (overrideable for testing).
+	kernelBuildFile = "/proc/sys/kernel/version"
+
+	// kernelRealtimeFile is the name of the file that marks a real-time
+	// kernel (overrideable for testing).
+	kernelRealtimeFile = "/sys/kernel/realtime"
+
+	// hugePagesDir is the directory that is read in order to determine the
+	// supported huge page sizes (overrideable for testing).
+	hugePagesDir = "/sys/kernel/mm/hugepages"
+
+	// usernsCloneFile and maxUserNamespacesFile are the names of the files
+	// that are read in order to determine whether unprivileged users can
+	// create user namespaces (overrideable for testing). Only Debian derived
+	// kernels have the former.
+	usernsCloneFile       = "/proc/sys/kernel/unprivileged_userns_clone"
+	maxUserNamespacesFile = "/proc/sys/user/max_user_namespaces"
+
+	// maxMapCountFile is the name of the file that is read in order to
+	// determine the maximum number of memory map areas a process may have
+	// (overrideable for testing).
+	maxMapCountFile = "/proc/sys/vm/max_map_count"
+
+	// fipsEnabledFile is the name of the file that is read in order to
+	// determine whether the kernel is in FIPS mode (overrideable for
+	// testing).
+	fipsEnabledFile = "/proc/sys/crypto/fips_enabled"
+
+	// seccompDir is the directory that is checked in order to determine
+	// whether the kernel supports seccomp, and kernelConfigDir holds the
+	// configurations of the installed kernels, which are consulted if it
+	// doesn't exist (overrideable for testing).
+	seccompDir      = "/proc/sys/kernel/seccomp"
+	kernelConfigDir = "/boot"
+)
+
+// KernelVersion returns the major and minor version of the host's kernel.
+func (h *Host) KernelVersion() (int, int, error) {
+	contents, err := ioutil.ReadFile(h.path(kernelReleaseFile))
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return parseKernelRelease(strings.TrimSpace(string(contents)))
+}
+
+// kernelAtLeast returns true if the host's kernel version is at least
+// major.minor.
+func (h *Host) kernelAtLeast(major, minor int) (bool, error) {
+	hostMajor, hostMinor, err := h.KernelVersion()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return hostMajor > major || (hostMajor == major && hostMinor >= minor), nil
+}
+
+// IOUringAvailable returns true if the host kernel supports io_uring. If the
+// kernel symbols can be read, io_uring_setup must also be present, as
+// io_uring can be compiled out of the kernel.
+func (h *Host) IOUringAvailable() (bool, error) {
+	ok, err := h.kernelAtLeast(5, 1)
+	if err != nil || !ok {
+		return false, errors.Trace(err)
+	}
+	f, err := os.Open(h.path(kallsymsFile))
+	if err != nil {
+		// The kernel symbols aren't always readable, so go by the version.
+		return true, nil
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if strings.HasSuffix(scanner.Text(), "io_uring_setup") {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.Annotatef(err, "reading %s", h.path(kallsymsFile))
+	}
+	return false, nil
+}
+
+// OverlayFSAvailable returns true if the host kernel supports the overlay
+// filesystem. A kernel that has overlay built as a module that hasn't been
+// loaded yet isn't reported as supporting it.
+func (h *Host) OverlayFSAvailable() (bool, error) {
+	f, err := os.Open(h.path(filesystemsFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Each line is the filesystem name, optionally preceded by
+		// "nodev".
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && fields[len(fields)-1] == "overlay" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.Annotatef(err, "reading %s", h.path(filesystemsFile))
+	}
+	return false, nil
+}
+
+// PIDMax returns the maximum process ID of the host.
+func (h *Host) PIDMax() (int, error) {
+	contents, err := ioutil.ReadFile(h.path(pidMaxFile))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	value := strings.TrimSpace(string(contents))
+	pidMax, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.NotValidf("pid_max %q", value)
+	}
+	return pidMax, nil
+}
+
+// IsRealtimeKernel returns true if the host is running a real-time kernel.
+// Real-time kernels provide /sys/kernel/realtime; older ones are only
+// identified by their build string, such as
+// "#1 SMP PREEMPT_RT Thu Jan 4 10:00:00 UTC 2024".
+func (h *Host) IsRealtimeKernel() (bool, error) {
+	contents, err := ioutil.ReadFile(h.path(kernelRealtimeFile))
+	if err == nil {
+		return strings.TrimSpace(string(contents)) == "1", nil
+	} else if !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	contents, err = ioutil.ReadFile(h.path(kernelBuildFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	build := string(contents)
+	return strings.Contains(build, "PREEMPT_RT") || strings.Contains(build, "PREEMPT RT"), nil
+}
+
+// HugePageSizes returns the huge page sizes supported by the host kernel.
+// Kernels built without huge page support have no sizes.
+func (h *Host) HugePageSizes() ([]string, error) {
+	entries, err := ioutil.ReadDir(h.path(hugePagesDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var (
+		sizes []string
+		kB    = make(map[string]int)
+	)
+	for _, entry := range entries {
+		// Each size has a directory such as "hugepages-2048kB".
+		size := strings.TrimPrefix(entry.Name(), "hugepages-")
+		if size == entry.Name() {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(size, "kB"))
+		if err != nil {
+			continue
+		}
+		sizes = append(sizes, size)
+		kB[size] = n
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return kB[sizes[i]] < kB[sizes[j]]
+	})
+	return sizes, nil
+}
+
+// UnprivilegedUserNSEnabled returns true if unprivileged users of the host
+// can create user namespaces. Kernels without max_user_namespaces don't
+// support user namespaces at all.
+func (h *Host) UnprivilegedUserNSEnabled() (bool, error) {
+	contents, err := ioutil.ReadFile(h.path(usernsCloneFile))
+	if err == nil {
+		if strings.TrimSpace(string(contents)) == "0" {
+			return false, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	contents, err = ioutil.ReadFile(h.path(maxUserNamespacesFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	value := strings.TrimSpace(string(contents))
+	max, err := strconv.Atoi(value)
+	if err != nil {
+		return false, errors.NotValidf("max_user_namespaces %q", value)
+	}
+	return max > 0, nil
+}
+
+// VMMaxMapCount returns the maximum number of memory map areas a process on
+// the host may have.
+func (h *Host) VMMaxMapCount() (int, error) {
+	contents, err := ioutil.ReadFile(h.path(maxMapCountFile))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	value := strings.TrimSpace(string(contents))
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.NotValidf("max_map_count %q", value)
+	}
+	return count, nil
+}
+
+// FIPSEnabled returns true if the host kernel is in FIPS mode. Kernels built
+// without FIPS support have no fips_enabled file.
+func (h *Host) FIPSEnabled() (bool, error) {
+	contents, err := ioutil.ReadFile(h.path(fipsEnabledFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	switch value := strings.TrimSpace(string(contents)); value {
+	case "0":
+		return false, nil
+	case "1":
+		return true, nil
+	default:
+		return false, errors.NotValidf("fips_enabled %q", value)
+	}
+}
+
+// SeccompAvailable returns true if the host kernel supports seccomp. Kernels
+// that predate /proc/sys/kernel/seccomp are checked for CONFIG_SECCOMP in the
+// configuration they were built with.
+func (h *Host) SeccompAvailable() (bool, error) {
+	if _, err := os.Stat(h.path(seccompDir)); err == nil {
+		return true, nil
+	} else if !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	contents, err := ioutil.ReadFile(h.path(kernelReleaseFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	release := strings.TrimSpace(string(contents))
+	config, err := ioutil.ReadFile(filepath.Join(h.path(kernelConfigDir), "config-"+release))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, line := range strings.Split(string(config), "\n") {
+		if strings.TrimSpace(line) == "CONFIG_SECCOMP=y" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type kernelSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&kernelSuite{})
+
+func (s *kernelSuite) TestIOUringAvailable(c *gc.C) {
+	s.PatchValue(series.KallsymsFile, filepath.Join(c.MkDir(), "kallsyms"))
+	for i, test := range []struct {
+		release  string
+		expected bool
+	}{
+		{"5.10.0-28-amd64", true},
+		{"5.15.0-91-generic", true},
+		{"6.8.0-31-generic", true},
+		{"5.1.0", true},
+		{"5.0.0-23-generic", false},
+		{"4.19.0-26-amd64", false},
+		{"3.10.0-1160.el7.x86_64", false},
+	} {
+		c.Logf("%d: %s", i, test.release)
+		patchFile(c, &s.CleanupSuite, series.KernelReleaseFile, test.release+"\n")
+
+		available, err := series.IOUringAvailable()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(available, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestIOUringAvailableKallsyms(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.KernelReleaseFile, "5.10.0-28-amd64\n")
+
+	patchFile(c, &s.CleanupSuite, series.KallsymsFile, "0000000000000000 T __x64_sys_io_uring_enter\n0000000000000000 T __x64_sys_io_uring_setup\n")
+	available, err := series.IOUringAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(available, jc.IsTrue)
+
+	patchFile(c, &s.CleanupSuite, series.KallsymsFile, "0000000000000000 T __x64_sys_read\n")
+	available, err = series.IOUringAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(available, jc.IsFalse)
+}
+
+func (s *kernelSuite) TestIOUringAvailableBadRelease(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.KernelReleaseFile, "garbage\n")
+
+	_, err := series.IOUringAvailable()
+	c.Assert(err, gc.ErrorMatches, `kernel release "garbage" not valid`)
+}
+
+func (s *kernelSuite) TestHostKernelVersion(c *gc.C) {
+	for i, test := range []struct {
+		release      string
+		major, minor int
+	}{
+		{"5.15.0-91-generic", 5, 15},
+		{"3.10.0-1160.el7.x86_64", 3, 10},
+		{"6.1.0-18-amd64", 6, 1},
+	} {
+		c.Logf("%d: %s", i, test.release)
+		patchFile(c, &s.CleanupSuite, series.KernelReleaseFile, test.release+"\n")
+
+		major, minor, err := series.HostKernelVersion()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(major, gc.Equals, test.major)
+		c.Check(minor, gc.Equals, test.minor)
+	}
+}
+
+func (s *kernelSuite) TestHostKernelVersionBadRelease(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.KernelReleaseFile, "garbage\n")
+
+	_, _, err := series.HostKernelVersion()
+	c.Assert(err, gc.ErrorMatches, `kernel release "garbage" not valid`)
+}
+
+func (s *kernelSuite) TestOverlayFSAvailable(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.FilesystemsFile, "nodev\tsysfs\nnodev\ttmpfs\n\text4\nnodev\toverlay\n")
+	available, err := series.OverlayFSAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(available, jc.IsTrue)
+
+	patchFile(c, &s.CleanupSuite, series.FilesystemsFile, "nodev\tsysfs\nnodev\ttmpfs\n\text4\n")
+	available, err = series.OverlayFSAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(available, jc.IsFalse)
+}
+
+func (s *kernelSuite) TestPIDMax(c *gc.C) {
+	for i, test := range []struct {
+		contents string
+		expected int
+	}{
+		{"32768\n", 32768},
+		{"4194304\n", 4194304},
+	} {
+		c.Logf("%d: %q", i, test.contents)
+		patchFile(c, &s.CleanupSuite, series.PIDMaxFile, test.contents)
+
+		pidMax, err := series.PIDMax()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(pidMax, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestPIDMaxNotValid(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.PIDMaxFile, "lots\n")
+
+	_, err := series.PIDMax()
+	c.Assert(err, gc.ErrorMatches, `pid_max "lots" not valid`)
+}
+
+func (s *kernelSuite) TestIsRealtimeKernel(c *gc.C) {
+	s.PatchValue(series.KernelRealtimeFile, filepath.Join(c.MkDir(), "realtime"))
+	for i, test := range []struct {
+		build    string
+		expected bool
+	}{
+		{"#1 SMP PREEMPT_RT Thu Jan  4 10:00:00 UTC 2024\n", true},
+		{"#1 SMP PREEMPT RT Fri Mar 13 09:00:00 UTC 2015\n", true},
+		{"#91-Ubuntu SMP Mon Nov 20 18:15:51 UTC 2023\n", false},
+		{"#1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1 (2024-02-01)\n", false},
+	} {
+		c.Logf("%d: %q", i, test.build)
+		patchFile(c, &s.CleanupSuite, series.KernelBuildFile, test.build)
+
+		realtime, err := series.IsRealtimeKernel()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(realtime, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestIsRealtimeKernelSysfs(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.KernelBuildFile, "#91-Ubuntu SMP Mon Nov 20 18:15:51 UTC 2023\n")
+	patchFile(c, &s.CleanupSuite, series.KernelRealtimeFile, "1\n")
+
+	realtime, err := series.IsRealtimeKernel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(realtime, jc.IsTrue)
+}
+
+func (s *kernelSuite) TestHugePageSizes(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"hugepages-1048576kB/nr_hugepages": "0\n",
+		"hugepages-2048kB/nr_hugepages":    "0\n",
+	})
+	s.PatchValue(series.HugePagesDir, root)
+
+	sizes, err := series.HugePageSizes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sizes, jc.DeepEquals, []string{"2048kB", "1048576kB"})
+}
+
+func (s *kernelSuite) TestHugePageSizesNotSupported(c *gc.C) {
+	s.PatchValue(series.HugePagesDir, filepath.Join(c.MkDir(), "hugepages"))
+
+	sizes, err := series.HugePageSizes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sizes, gc.HasLen, 0)
+}
+
+func (s *kernelSuite) TestUnprivilegedUserNSEnabled(c *gc.C) {
+	for i, test := range []struct {
+		message     string
+		usernsClone string
+		maxUserNS   string
+		expected    bool
+	}{{
+		message:   "max_user_namespaces only",
+		maxUserNS: "63338\n",
+		expected:  true,
+	}, {
+		message:   "max_user_namespaces zero",
+		maxUserNS: "0\n",
+		expected:  false,
+	}, {
+		message:     "debian enabled",
+		usernsClone: "1\n",
+		maxUserNS:   "63338\n",
+		expected:    true,
+	}, {
+		message:     "debian disabled",
+		usernsClone: "0\n",
+		maxUserNS:   "63338\n",
+		expected:    false,
+	}, {
+		message:     "debian enabled with max_user_namespaces zero",
+		usernsClone: "1\n",
+		maxUserNS:   "0\n",
+		expected:    false,
+	}, {
+		message:  "no user namespaces",
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		files := make(map[string]string)
+		if test.usernsClone != "" {
+			files["/proc/sys/kernel/unprivileged_userns_clone"] = test.usernsClone
+		}
+		if test.maxUserNS != "" {
+			files["/proc/sys/user/max_user_namespaces"] = test.maxUserNS
+		}
+		h := &series.Host{Root: makeHostRoot(c, files)}
+
+		enabled, err := h.UnprivilegedUserNSEnabled()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(enabled, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestUnprivilegedUserNSEnabledOverride(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.UsernsCloneFile, "0\n")
+	patchFile(c, &s.CleanupSuite, series.MaxUserNamespacesFile, "63338\n")
+
+	enabled, err := series.UnprivilegedUserNSEnabled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsFalse)
+}
+
+func (s *kernelSuite) TestVMMaxMapCount(c *gc.C) {
+	for i, test := range []struct {
+		contents string
+		expected int
+	}{
+		{"65530\n", 65530},
+		{"262144\n", 262144},
+	} {
+		c.Logf("%d: %q", i, test.contents)
+		patchFile(c, &s.CleanupSuite, series.MaxMapCountFile, test.contents)
+
+		count, err := series.VMMaxMapCount()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(count, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestVMMaxMapCountNotValid(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.MaxMapCountFile, "lots\n")
+
+	_, err := series.VMMaxMapCount()
+	c.Assert(err, gc.ErrorMatches, `max_map_count "lots" not valid`)
+}
+
+func (s *kernelSuite) TestFIPSEnabled(c *gc.C) {
+	for i, test := range []struct {
+		contents string
+		expected bool
+	}{
+		{"0\n", false},
+		{"1\n", true},
+	} {
+		c.Logf("%d: %q", i, test.contents)
+		patchFile(c, &s.CleanupSuite, series.FIPSEnabledFile, test.contents)
+
+		enabled, err := series.FIPSEnabled()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(enabled, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestFIPSEnabledMissingFile(c *gc.C) {
+	s.PatchValue(series.FIPSEnabledFile, filepath.Join(c.MkDir(), "fips_enabled"))
+
+	enabled, err := series.FIPSEnabled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsFalse)
+}
+
+func (s *kernelSuite) TestFIPSEnabledNotValid(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.FIPSEnabledFile, "yes\n")
+
+	_, err := series.FIPSEnabled()
+	c.Assert(err, gc.ErrorMatches, `fips_enabled "yes" not valid`)
+}
+
+func (s *kernelSuite) TestSeccompAvailable(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		files    map[string]string
+		expected bool
+	}{{
+		message: "proc",
+		files: map[string]string{
+			"/proc/sys/kernel/seccomp/actions_avail": "kill_process kill_thread trap errno user_notif trace log allow\n",
+		},
+		expected: true,
+	}, {
+		message: "kernel config",
+		files: map[string]string{
+			"/proc/sys/kernel/osrelease":          "3.10.0-1160.el7.x86_64\n",
+			"/boot/config-3.10.0-1160.el7.x86_64": "CONFIG_SECCOMP_FILTER=y\nCONFIG_SECCOMP=y\n",
+		},
+		expected: true,
+	}, {
+		message: "kernel config without seccomp",
+		files: map[string]string{
+			"/proc/sys/kernel/osrelease": "3.2.0-4-amd64\n",
+			"/boot/config-3.2.0-4-amd64": "# CONFIG_SECCOMP is not set\n",
+		},
+		expected: false,
+	}, {
+		message: "no kernel config",
+		files: map[string]string{
+			"/proc/sys/kernel/osrelease": "3.2.0-4-amd64\n",
+		},
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		h := &series.Host{Root: makeHostRoot(c, test.files)}
+
+		available, err := h.SeccompAvailable()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(available, gc.Equals, test.expected)
+	}
+}
+
+func (s *kernelSuite) TestSeccompAvailableOverride(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		"config-6.8.0-31-generic": "CONFIG_SECCOMP=y\n",
+	})
+	s.PatchValue(series.SeccompDir, filepath.Join(c.MkDir(), "seccomp"))
+	s.PatchValue(series.KernelConfigDir, root)
+	patchFile(c, &s.CleanupSuite, series.KernelReleaseFile, "6.8.0-31-generic\n")
+
+	available, err := series.SeccompAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(available, jc.IsTrue)
+}
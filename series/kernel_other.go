@@ -0,0 +1,66 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+// KernelVersion returns the major and minor version of the kernel release
+// reported by uname.
+func (h *Host) KernelVersion() (int, int, error) {
+	release, err := h.unameRelease()
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return parseKernelRelease(release)
+}
+
+// IOUringAvailable is only supported on Linux.
+func (h *Host) IOUringAvailable() (bool, error) {
+	return false, errors.NotSupportedf("io_uring detection")
+}
+
+// OverlayFSAvailable is only supported on Linux.
+func (h *Host) OverlayFSAvailable() (bool, error) {
+	return false, errors.NotSupportedf("overlayfs detection")
+}
+
+// PIDMax is only supported on Linux.
+func (h *Host) PIDMax() (int, error) {
+	return 0, errors.NotSupportedf("pid_max detection")
+}
+
+// IsRealtimeKernel is only supported on Linux.
+func (h *Host) IsRealtimeKernel() (bool, error) {
+	return false, errors.NotSupportedf("real-time kernel detection")
+}
+
+// HugePageSizes is only supported on Linux.
+func (h *Host) HugePageSizes() ([]string, error) {
+	return nil, errors.NotSupportedf("huge page detection")
+}
+
+// UnprivilegedUserNSEnabled is only supported on Linux.
+func (h *Host) UnprivilegedUserNSEnabled() (bool, error) {
+	return false, errors.NotSupportedf("user namespace detection")
+}
+
+// VMMaxMapCount is only supported on Linux.
+func (h *Host) VMMaxMapCount() (int, error) {
+	return 0, errors.NotSupportedf("max_map_count detection")
+}
+
+// FIPSEnabled is only supported on Linux.
+func (h *Host) FIPSEnabled() (bool, error) {
+	return false, errors.NotSupportedf("FIPS mode detection")
+}
+
+// SeccompAvailable is only supported on Linux.
+func (h *Host) SeccompAvailable() (bool, error) {
+	return false, errors.NotSupportedf("seccomp detection")
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// binDir is the directory that is checked in order to determine
+	// whether /usr is merged (overrideable for testing).
+	binDir = "/bin"
+)
+
+// UsrMerged returns true if the host has merged /usr, that is /bin is a
+// symlink to /usr/bin.
+func UsrMerged() (bool, error) {
+	return defaultHost.UsrMerged()
+}
+
+// UsrMerged returns true if /bin on the host is a symlink to /usr/bin.
+func (h *Host) UsrMerged() (bool, error) {
+	bin := h.path(binDir)
+	info, err := os.Lstat(bin)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		return false, nil
+	}
+	target, err := os.Readlink(bin)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	// The link is usually relative, "usr/bin", but may be absolute.
+	return strings.TrimPrefix(filepath.Clean(target), "/") == "usr/bin", nil
+}
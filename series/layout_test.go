@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type layoutSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&layoutSuite{})
+
+func (s *layoutSuite) TestUsrMerged(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("symlinks require elevated privileges on windows")
+	}
+	root := c.MkDir()
+	err := os.MkdirAll(filepath.Join(root, "usr", "bin"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink("usr/bin", filepath.Join(root, "bin"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.BinDir, filepath.Join(root, "bin"))
+
+	merged, err := series.UsrMerged()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(merged, jc.IsTrue)
+}
+
+func (s *layoutSuite) TestUsrNotMerged(c *gc.C) {
+	bin := filepath.Join(c.MkDir(), "bin")
+	err := os.Mkdir(bin, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.BinDir, bin)
+
+	merged, err := series.UsrMerged()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(merged, jc.IsFalse)
+}
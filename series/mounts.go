@@ -0,0 +1,17 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// RootFSType returns the type of the filesystem backing "/", such as "ext4",
+// "xfs", "btrfs" or "zfs".
+func RootFSType() (string, error) {
+	return defaultHost.RootFSType()
+}
+
+// EtcIsOverlay returns true if /etc is an overlay mount, as on some
+// image-based distributions, so writes to it might not persist across
+// updates of the image.
+func EtcIsOverlay() (bool, error) {
+	return defaultHost.EtcIsOverlay()
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// mountsFile is the name of the file that is read in order to determine
+	// the mounted filesystems (overrideable for testing).
+	mountsFile = "/proc/mounts"
+)
+
+// mount holds the information about a single mounted filesystem.
+type mount struct {
+	Device     string
+	MountPoint string
+	FSType     string
+	Options    []string
+}
+
+// readMounts parses the mounted filesystems from the mounts file, in the
+// order in which they were mounted.
+func (h *Host) readMounts() ([]mount, error) {
+	f, err := os.Open(h.path(mountsFile))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var mounts []mount
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		mounts = append(mounts, mount{
+			Device:     fields[0],
+			MountPoint: fields[1],
+			FSType:     fields[2],
+			Options:    strings.Split(fields[3], ","),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotatef(err, "reading %s", h.path(mountsFile))
+	}
+	return mounts, nil
+}
+
+// mountFor returns the filesystem mounted at the mount point. If there are
+// several, the last one mounted is returned, as that hides the others.
+func (h *Host) mountFor(mountPoint string) (mount, error) {
+	mounts, err := h.readMounts()
+	if err != nil {
+		return mount{}, errors.Trace(err)
+	}
+	var (
+		result mount
+		found  bool
+	)
+	for _, m := range mounts {
+		// The initial rootfs is always overmounted by the real root.
+		if m.MountPoint != mountPoint || m.FSType == "rootfs" {
+			continue
+		}
+		result, found = m, true
+	}
+	if !found {
+		return mount{}, errors.NotFoundf("mount for %q", mountPoint)
+	}
+	return result, nil
+}
+
+// RootFSType returns the type of the filesystem backing the host's "/".
+func (h *Host) RootFSType() (string, error) {
+	m, err := h.mountFor("/")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return m.FSType, nil
+}
+
+// EtcIsOverlay returns true if the host's /etc is an overlay mount. An /etc
+// without its own mount is part of the root filesystem, and not an overlay.
+func (h *Host) EtcIsOverlay() (bool, error) {
+	m, err := h.mountFor("/etc")
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return m.FSType == "overlay", nil
+}
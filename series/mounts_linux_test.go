@@ -0,0 +1,94 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type mountsSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&mountsSuite{})
+
+func (s *mountsSuite) TestRootFSType(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		contents string
+		expected string
+	}{{
+		message: "ext4",
+		contents: `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda1 / ext4 rw,relatime 0 0
+/dev/sda15 /boot/efi vfat rw,relatime 0 0
+`,
+		expected: "ext4",
+	}, {
+		message: "btrfs",
+		contents: `rootfs / rootfs rw 0 0
+/dev/nvme0n1p2 / btrfs rw,relatime,ssd,space_cache=v2,subvol=/@ 0 0
+/dev/nvme0n1p2 /home btrfs rw,relatime,ssd,space_cache=v2,subvol=/@home 0 0
+`,
+		expected: "btrfs",
+	}, {
+		message: "zfs",
+		contents: `rpool/ROOT/ubuntu_abc123 / zfs rw,relatime,xattr,posixacl 0 0
+bpool/BOOT/ubuntu_abc123 /boot zfs rw,nodev,relatime,xattr,posixacl 0 0
+`,
+		expected: "zfs",
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.MountsFile, test.contents)
+
+		fsType, err := series.RootFSType()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(fsType, gc.Equals, test.expected)
+	}
+}
+
+func (s *mountsSuite) TestRootFSTypeNotFound(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.MountsFile, "proc /proc proc rw 0 0\n")
+
+	_, err := series.RootFSType()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *mountsSuite) TestEtcIsOverlay(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		contents string
+		expected bool
+	}{{
+		message: "overlay",
+		contents: `/dev/vda3 / ext4 ro,relatime 0 0
+overlay /etc overlay rw,relatime,lowerdir=/usr/etc,upperdir=/var/etc/upper,workdir=/var/etc/work 0 0
+`,
+		expected: true,
+	}, {
+		message: "separate mount",
+		contents: `/dev/vda3 / ext4 rw,relatime 0 0
+/dev/vda4 /etc ext4 rw,relatime 0 0
+`,
+		expected: false,
+	}, {
+		message: "root filesystem",
+		contents: `/dev/sda1 / ext4 rw,relatime 0 0
+`,
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.MountsFile, test.contents)
+
+		overlay, err := series.EtcIsOverlay()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(overlay, gc.Equals, test.expected)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+// RootFSType is only supported on Linux.
+func (h *Host) RootFSType() (string, error) {
+	return "", errors.NotSupportedf("root filesystem detection")
+}
+
+// EtcIsOverlay is only supported on Linux.
+func (h *Host) EtcIsOverlay() (bool, error) {
+	return false, errors.NotSupportedf("/etc overlay detection")
+}
@@ -0,0 +1,406 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os/v2"
+)
+
+var (
+	// hostsFile is the name of the file that is read in order to determine
+	// the static host name entries (overrideable for testing).
+	hostsFile = "/etc/hosts"
+
+	// hostnameFile is the name of the file that is read in order to
+	// determine the configured host name (overrideable for testing).
+	hostnameFile = "/etc/hostname"
+
+	// sshdBinary and sshdConfigFile are the names of the files that are
+	// checked in order to determine whether an SSH server is installed,
+	// and the port it listens on (overrideable for testing).
+	sshdBinary     = "/usr/sbin/sshd"
+	sshdConfigFile = "/etc/ssh/sshd_config"
+
+	// resolvConfFile is the name of the file that is checked in order to
+	// determine how DNS resolution is configured (overrideable for
+	// testing).
+	resolvConfFile = "/etc/resolv.conf"
+
+	// networkManagerConfigFile and networkManagerConfigDir are the names
+	// of the NetworkManager configuration files that are read in order to
+	// determine the connection defaults (overrideable for testing).
+	networkManagerConfigFile = "/etc/NetworkManager/NetworkManager.conf"
+	networkManagerConfigDir  = "/etc/NetworkManager/conf.d"
+
+	// osHostname, lookupHost and lookupAddr are used to resolve the host
+	// name (overrideable for testing).
+	osHostname = os.Hostname
+	lookupHost = net.LookupHost
+	lookupAddr = net.LookupAddr
+)
+
+// HasUbuntuHostsEntry returns true if the hosts file contains a 127.0.1.1
+// entry, which Ubuntu adds for the machine's own host name.
+func HasUbuntuHostsEntry() (bool, error) {
+	return defaultHost.HasUbuntuHostsEntry()
+}
+
+// HasUbuntuHostsEntry returns true if the host's hosts file contains a
+// 127.0.1.1 entry.
+func (h *Host) HasUbuntuHostsEntry() (bool, error) {
+	f, err := os.Open(h.path(hostsFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "127.0.1.1" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.Annotatef(err, "reading %s", h.path(hostsFile))
+	}
+	return false, nil
+}
+
+// Hostname returns the short host name and the fully qualified domain name
+// of the machine the current process is running on. The configured host name
+// is taken from /etc/hostname, falling back to the kernel's host name, and
+// the FQDN is found by a reverse lookup of its addresses. If no FQDN can be
+// resolved, the short host name is returned for both.
+func Hostname() (short string, fqdn string, err error) {
+	return defaultHost.Hostname()
+}
+
+// Hostname returns the short host name and the fully qualified domain name
+// of the host. If the host has a Root, only its hostname file is consulted,
+// as the kernel's host name and DNS belong to the machine the current process
+// is running on.
+func (h *Host) Hostname() (short string, fqdn string, err error) {
+	name, err := h.configuredHostname()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	name = strings.TrimSuffix(name, ".")
+	if i := strings.Index(name, "."); i > 0 {
+		return name[:i], name, nil
+	}
+	if h.Root != "" {
+		return name, name, nil
+	}
+
+	addrs, err := lookupHost(name)
+	if err != nil {
+		logger.Debugf("unable to resolve host name %q: %v", name, err)
+		return name, name, nil
+	}
+	for _, addr := range addrs {
+		names, err := lookupAddr(addr)
+		if err != nil {
+			continue
+		}
+		for _, candidate := range names {
+			candidate = strings.TrimSuffix(candidate, ".")
+			if strings.HasPrefix(candidate, name+".") {
+				return name, candidate, nil
+			}
+		}
+	}
+	return name, name, nil
+}
+
+func (h *Host) configuredHostname() (string, error) {
+	contents, err := ioutil.ReadFile(h.path(hostnameFile))
+	if err == nil {
+		if name := strings.TrimSpace(string(contents)); name != "" {
+			return name, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", errors.Trace(err)
+	}
+	if h.Root != "" {
+		return "", errors.NotFoundf("host name in %s", h.path(hostnameFile))
+	}
+	return osHostname()
+}
+
+// SSHDInfo returns whether an SSH server is installed, and the port that it
+// listens on according to its configuration. The port defaults to 22 when
+// the configuration doesn't set one.
+func SSHDInfo() (present bool, port int, err error) {
+	return defaultHost.SSHDInfo()
+}
+
+// SSHDInfo returns whether an SSH server is installed on the host, and the
+// port that it listens on.
+func (h *Host) SSHDInfo() (present bool, port int, err error) {
+	if _, err := os.Stat(h.path(sshdBinary)); os.IsNotExist(err) {
+		return false, 0, nil
+	} else if err != nil {
+		return false, 0, errors.Trace(err)
+	}
+
+	f, err := os.Open(h.path(sshdConfigFile))
+	if os.IsNotExist(err) {
+		return true, 22, nil
+	} else if err != nil {
+		return false, 0, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Keywords are case insensitive, and the first value obtained is
+		// the one used.
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "Port") {
+			continue
+		}
+		port, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return false, 0, errors.NotValidf("sshd port %q", fields[1])
+		}
+		return true, port, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, 0, errors.Annotatef(err, "reading %s", h.path(sshdConfigFile))
+	}
+	return true, 22, nil
+}
+
+// IPv4ForwardingEnabled returns true if the kernel forwards IPv4 packets
+// between interfaces, as configured by the net.ipv4.ip_forward sysctl.
+func IPv4ForwardingEnabled() (bool, error) {
+	return defaultHost.IPv4ForwardingEnabled()
+}
+
+// HasNATRules returns true if the host's firewall already has source NAT
+// rules, masquerading or otherwise, which networking configuration must not
+// clobber. The ruleset is read with iptables-save, falling back to nft for
+// hosts without iptables.
+func HasNATRules() (bool, error) {
+	return defaultHost.HasNATRules()
+}
+
+// HasNATRules returns true if the host's firewall has source NAT rules.
+func (h *Host) HasNATRules() (bool, error) {
+	out, err := h.runner().Run("iptables-save", "-t", "nat")
+	if err == nil {
+		for _, field := range strings.Fields(out) {
+			if field == "MASQUERADE" || field == "SNAT" {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	out, nftErr := h.runner().Run("nft", "list", "ruleset")
+	if nftErr != nil {
+		return false, errors.Annotatef(nftErr, "reading NAT rules (iptables-save: %v)", err)
+	}
+	for _, field := range strings.Fields(out) {
+		if field == "masquerade" || field == "snat" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// predictableInterfaceNamesSince holds the first release of each OS whose
+// network interfaces have systemd's predictable names, such as "enp3s0",
+// rather than kernel names such as "eth0": Ubuntu switched over in 15.10
+// (wily) and CentOS in 7.
+var predictableInterfaceNamesSince = map[jujuos.OSType]string{
+	jujuos.Ubuntu: "15.10",
+	jujuos.CentOS: "7",
+}
+
+// PredictableInterfaceNames returns true if the network interfaces of the
+// series have systemd's predictable names, rather than eth0 style names.
+// Only Ubuntu and CentOS series are supported.
+func PredictableInterfaceNames(series string) (bool, error) {
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	since, ok := predictableInterfaceNamesSince[osType]
+	if !ok {
+		return false, errors.NotSupportedf("interface naming for %s series %q", osType, series)
+	}
+	var version string
+	switch osType {
+	case jujuos.Ubuntu:
+		if version, err = UbuntuSeriesVersion(series); err != nil {
+			return false, errors.Trace(err)
+		}
+		version = strings.TrimSuffix(version, " LTS")
+	case jujuos.CentOS:
+		version = strings.TrimPrefix(centosSeries[series], "centos")
+	}
+	cmp, err := compareVersions(version, since)
+	if err != nil {
+		return false, errors.Annotatef(err, "version %q of series %q", version, series)
+	}
+	return cmp >= 0, nil
+}
+
+// InterfaceManager returns the network manager that configures the network
+// interface: "systemd-networkd", "NetworkManager", or an empty string if
+// neither manages it. Managers that aren't installed or running are skipped.
+func InterfaceManager(iface string) (string, error) {
+	return defaultHost.InterfaceManager(iface)
+}
+
+// InterfaceManager returns the network manager that configures the host's
+// network interface.
+func (h *Host) InterfaceManager(iface string) (string, error) {
+	// networkctl reports the setup state of the link, such as
+	// "State: routable (configured)" or "State: off (unmanaged)".
+	if out, err := h.runner().Run("networkctl", "status", "--no-pager", iface); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 || fields[0] != "State:" {
+				continue
+			}
+			switch fields[2] {
+			case "(configured)", "(configuring)":
+				return "systemd-networkd", nil
+			}
+			break
+		}
+	}
+	// nmcli reports one device per line, such as "eth0:connected".
+	if out, err := h.runner().Run("nmcli", "-t", "-f", "DEVICE,STATE", "device", "status"); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			parts := strings.SplitN(strings.TrimSpace(line), ":", 2)
+			if len(parts) != 2 || parts[0] != iface {
+				continue
+			}
+			switch parts[1] {
+			case "unmanaged", "unavailable":
+				return "", nil
+			}
+			return "NetworkManager", nil
+		}
+	}
+	return "", nil
+}
+
+// ResolvConfMode returns how the resolv.conf file is managed:
+// "systemd-resolved" or "resolvconf" if it is a symlink to a file generated
+// by one of them, otherwise "static".
+func ResolvConfMode() (string, error) {
+	return defaultHost.ResolvConfMode()
+}
+
+// ResolvConfMode returns how the host's resolv.conf file is managed.
+func (h *Host) ResolvConfMode() (string, error) {
+	path := h.path(resolvConfFile)
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		return "static", nil
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch {
+	case strings.Contains(target, "/systemd/resolve/"):
+		// Either the stub-resolv.conf or resolv.conf of systemd-resolved.
+		return "systemd-resolved", nil
+	case strings.Contains(target, "/resolvconf/"):
+		return "resolvconf", nil
+	}
+	return "static", nil
+}
+
+// MACRandomizationEnabled returns true if NetworkManager is configured to
+// randomize the MAC address of WiFi connections by default. Hosts without
+// NetworkManager configuration use the permanent MAC address.
+func MACRandomizationEnabled() (bool, error) {
+	return defaultHost.MACRandomizationEnabled()
+}
+
+// MACRandomizationEnabled returns true if the host's NetworkManager
+// randomizes the MAC address of WiFi connections by default. The drop-in
+// files of conf.d are read after the main configuration, in name order, so
+// that later settings override earlier ones.
+func (h *Host) MACRandomizationEnabled() (bool, error) {
+	files := []string{h.path(networkManagerConfigFile)}
+	dropIns, err := filepath.Glob(filepath.Join(h.path(networkManagerConfigDir), "*.conf"))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	sort.Strings(dropIns)
+	files = append(files, dropIns...)
+
+	enabled := false
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		var section string
+		for _, line := range strings.Split(string(contents), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || line[0] == '#' || line[0] == ';' {
+				continue
+			}
+			if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+				section = line[1 : len(line)-1]
+				continue
+			}
+			// Connection defaults are held by [connection] sections,
+			// which may have a suffix such as [connection-wifi].
+			if !strings.HasPrefix(section, "connection") {
+				continue
+			}
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			switch key {
+			case "wifi.cloned-mac-address":
+				enabled = value == "random" || value == "stable"
+			case "wifi.mac-address-randomization":
+				// The deprecated setting is 0 (default), 1 (never) or 2
+				// (always).
+				switch value {
+				case "1":
+					enabled = false
+				case "2":
+					enabled = true
+				}
+			}
+		}
+	}
+	return enabled, nil
+}
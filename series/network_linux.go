@@ -0,0 +1,28 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// ipForwardFile is the name of the file that is read in order to
+	// determine whether IPv4 forwarding is enabled (overrideable for
+	// testing).
+	ipForwardFile = "/proc/sys/net/ipv4/ip_forward"
+)
+
+// IPv4ForwardingEnabled returns true if the host's kernel forwards IPv4
+// packets.
+func (h *Host) IPv4ForwardingEnabled() (bool, error) {
+	contents, err := ioutil.ReadFile(h.path(ipForwardFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return strings.TrimSpace(string(contents)) == "1", nil
+}
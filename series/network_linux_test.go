@@ -0,0 +1,36 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+func (s *networkSuite) TestIPv4ForwardingEnabled(c *gc.C) {
+	for _, test := range []struct {
+		contents string
+		expected bool
+	}{
+		{"1\n", true},
+		{"0\n", false},
+	} {
+		patchFile(c, &s.CleanupSuite, series.IPForwardFile, test.contents)
+
+		enabled, err := series.IPv4ForwardingEnabled()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(enabled, gc.Equals, test.expected, gc.Commentf("%q", test.contents))
+	}
+}
+
+func (s *networkSuite) TestIPv4ForwardingEnabledMissingFile(c *gc.C) {
+	s.PatchValue(series.IPForwardFile, filepath.Join(c.MkDir(), "ip_forward"))
+
+	_, err := series.IPv4ForwardingEnabled()
+	c.Assert(err, gc.NotNil)
+}
@@ -0,0 +1,363 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type networkSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&networkSuite{})
+
+func (s *networkSuite) TestHasUbuntuHostsEntry(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		contents string
+		expected bool
+	}{{
+		message: "ubuntu hosts file",
+		contents: `127.0.0.1	localhost
+127.0.1.1	juju-machine-0
+
+# The following lines are desirable for IPv6 capable hosts
+::1     ip6-localhost ip6-loopback
+`,
+		expected: true,
+	}, {
+		message: "no 127.0.1.1 entry",
+		contents: `127.0.0.1   localhost localhost.localdomain
+::1         localhost localhost.localdomain
+`,
+		expected: false,
+	}, {
+		message: "commented out entry",
+		contents: `127.0.0.1	localhost
+#127.0.1.1	juju-machine-0
+`,
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.HostsFile, test.contents)
+
+		found, err := series.HasUbuntuHostsEntry()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(found, gc.Equals, test.expected)
+	}
+}
+
+func (s *networkSuite) TestHasUbuntuHostsEntryMissingFile(c *gc.C) {
+	s.PatchValue(series.HostsFile, filepath.Join(c.MkDir(), "hosts"))
+
+	_, err := series.HasUbuntuHostsEntry()
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *networkSuite) patchResolver(c *gc.C, hostname string, addrs map[string][]string, names map[string][]string) {
+	s.PatchValue(series.HostnameFile, filepath.Join(c.MkDir(), "hostname"))
+	s.PatchValue(series.OSHostname, func() (string, error) {
+		return hostname, nil
+	})
+	s.PatchValue(series.LookupHost, func(host string) ([]string, error) {
+		if a, ok := addrs[host]; ok {
+			return a, nil
+		}
+		return nil, errors.New("no such host")
+	})
+	s.PatchValue(series.LookupAddr, func(addr string) ([]string, error) {
+		if n, ok := names[addr]; ok {
+			return n, nil
+		}
+		return nil, errors.New("no such address")
+	})
+}
+
+func (s *networkSuite) TestHostname(c *gc.C) {
+	s.patchResolver(c, "juju-machine-0",
+		map[string][]string{"juju-machine-0": {"10.0.0.5"}},
+		map[string][]string{"10.0.0.5": {"juju-machine-0.example.com."}},
+	)
+
+	short, fqdn, err := series.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(short, gc.Equals, "juju-machine-0")
+	c.Check(fqdn, gc.Equals, "juju-machine-0.example.com")
+}
+
+func (s *networkSuite) TestHostnameFromFile(c *gc.C) {
+	s.patchResolver(c, "kernel-name", nil, nil)
+	err := ioutil.WriteFile(*series.HostnameFile, []byte("web1.example.com\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	short, fqdn, err := series.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(short, gc.Equals, "web1")
+	c.Check(fqdn, gc.Equals, "web1.example.com")
+}
+
+func (s *networkSuite) TestHostnameUnresolvable(c *gc.C) {
+	s.patchResolver(c, "juju-machine-0", nil, nil)
+
+	short, fqdn, err := series.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(short, gc.Equals, "juju-machine-0")
+	c.Check(fqdn, gc.Equals, "juju-machine-0")
+}
+
+func (s *networkSuite) TestSSHDInfo(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.SSHDBinary, "")
+	for i, test := range []struct {
+		message  string
+		config   string
+		expected int
+	}{{
+		message:  "custom port",
+		config:   "# Port 22\nPort 2222\nPermitRootLogin no\n",
+		expected: 2222,
+	}, {
+		message:  "first port wins",
+		config:   "port 2200\nPort 2222\n",
+		expected: 2200,
+	}, {
+		message:  "default port",
+		config:   "#Port 22\nPasswordAuthentication no\n",
+		expected: 22,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.SSHDConfigFile, test.config)
+
+		present, port, err := series.SSHDInfo()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(present, jc.IsTrue)
+		c.Check(port, gc.Equals, test.expected)
+	}
+}
+
+func (s *networkSuite) TestSSHDInfoNoConfig(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.SSHDBinary, "")
+	s.PatchValue(series.SSHDConfigFile, filepath.Join(c.MkDir(), "sshd_config"))
+
+	present, port, err := series.SSHDInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(present, jc.IsTrue)
+	c.Check(port, gc.Equals, 22)
+}
+
+func (s *networkSuite) TestSSHDInfoNotInstalled(c *gc.C) {
+	s.PatchValue(series.SSHDBinary, filepath.Join(c.MkDir(), "sshd"))
+
+	present, _, err := series.SSHDInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(present, jc.IsFalse)
+}
+
+func (s *networkSuite) TestHasNATRules(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		output   map[string]string
+		expected bool
+	}{{
+		message: "iptables masquerade",
+		output: map[string]string{"iptables-save -t nat": "*nat\n" +
+			":POSTROUTING ACCEPT [0:0]\n" +
+			"-A POSTROUTING -s 10.0.3.0/24 ! -d 10.0.3.0/24 -j MASQUERADE\n" +
+			"COMMIT\n"},
+		expected: true,
+	}, {
+		message: "iptables snat",
+		output: map[string]string{"iptables-save -t nat": "*nat\n" +
+			"-A POSTROUTING -o eth0 -j SNAT --to-source 192.0.2.1\n" +
+			"COMMIT\n"},
+		expected: true,
+	}, {
+		message: "iptables without nat",
+		output: map[string]string{"iptables-save -t nat": "*nat\n" +
+			":PREROUTING ACCEPT [0:0]\n" +
+			":POSTROUTING ACCEPT [0:0]\n" +
+			"COMMIT\n"},
+		expected: false,
+	}, {
+		message: "nft masquerade",
+		output: map[string]string{"nft list ruleset": "table ip nat {\n" +
+			"\tchain postrouting {\n" +
+			"\t\ttype nat hook postrouting priority srcnat; policy accept;\n" +
+			"\t\tip saddr 10.0.3.0/24 masquerade\n" +
+			"\t}\n}\n"},
+		expected: true,
+	}, {
+		message: "nft without nat",
+		output: map[string]string{"nft list ruleset": "table inet filter {\n" +
+			"\tchain input {\n" +
+			"\t\ttype filter hook input priority filter; policy accept;\n" +
+			"\t}\n}\n"},
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		restore := series.SetCommandRunner(&fakeRunner{output: test.output})
+
+		found, err := series.HasNATRules()
+		restore()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(found, gc.Equals, test.expected)
+	}
+}
+
+func (s *networkSuite) TestHasNATRulesNoFirewall(c *gc.C) {
+	restore := series.SetCommandRunner(&fakeRunner{})
+	defer restore()
+
+	_, err := series.HasNATRules()
+	c.Assert(err, gc.ErrorMatches, `reading NAT rules \(iptables-save: running iptables-save: exit status 1\): running nft: exit status 1`)
+}
+
+func (s *networkSuite) TestPredictableInterfaceNames(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+	for _, test := range []struct {
+		series   string
+		expected bool
+	}{
+		{"precise", false},
+		{"vivid", false},
+		{"wily", true},
+		{"xenial", true},
+		{"jammy", true},
+		{"centos7", true},
+		{"centos9", true},
+	} {
+		predictable, err := series.PredictableInterfaceNames(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(predictable, gc.Equals, test.expected, gc.Commentf("%s", test.series))
+	}
+}
+
+func (s *networkSuite) TestPredictableInterfaceNamesNotSupported(c *gc.C) {
+	_, err := series.PredictableInterfaceNames("win2019")
+	c.Assert(err, gc.ErrorMatches, `interface naming for Windows series "win2019" not supported`)
+}
+
+const networkctlStatus = `● 2: %s
+                     Link File: /usr/lib/systemd/network/99-default.link
+                  Network File: %s
+                         State: %s
+                  Online state: online
+                          Type: ether
+`
+
+func (s *networkSuite) TestInterfaceManager(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		output   map[string]string
+		expected string
+	}{{
+		message: "networkd",
+		output: map[string]string{
+			"networkctl status --no-pager eth0": fmt.Sprintf(networkctlStatus,
+				"eth0", "/run/systemd/network/10-netplan-eth0.network", "routable (configured)"),
+		},
+		expected: "systemd-networkd",
+	}, {
+		message: "NetworkManager",
+		output: map[string]string{
+			"networkctl status --no-pager eth0": fmt.Sprintf(networkctlStatus,
+				"eth0", "n/a", "routable (unmanaged)"),
+			"nmcli -t -f DEVICE,STATE device status": "eth0:connected\nlo:connected (externally)\n",
+		},
+		expected: "NetworkManager",
+	}, {
+		message: "NetworkManager without networkd",
+		output: map[string]string{
+			"nmcli -t -f DEVICE,STATE device status": "wlan0:disconnected\neth0:connected\n",
+		},
+		expected: "NetworkManager",
+	}, {
+		message: "unmanaged by NetworkManager",
+		output: map[string]string{
+			"nmcli -t -f DEVICE,STATE device status": "eth0:unmanaged\n",
+		},
+		expected: "",
+	}, {
+		message:  "no managers",
+		output:   map[string]string{},
+		expected: "",
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		restore := series.SetCommandRunner(&fakeRunner{output: test.output})
+
+		manager, err := series.InterfaceManager("eth0")
+		restore()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(manager, gc.Equals, test.expected)
+	}
+}
+
+func (s *networkSuite) TestMACRandomizationEnabled(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		files    map[string]string
+		expected bool
+	}{{
+		message: "random",
+		files: map[string]string{
+			"/etc/NetworkManager/NetworkManager.conf": "[main]\nplugins=ifupdown,keyfile\n\n[connection]\nwifi.cloned-mac-address=random\n",
+		},
+		expected: true,
+	}, {
+		message: "stable in a drop-in",
+		files: map[string]string{
+			"/etc/NetworkManager/NetworkManager.conf":       "[main]\nplugins=ifupdown,keyfile\n",
+			"/etc/NetworkManager/conf.d/30-mac-random.conf": "[connection-mac-randomization]\nwifi.cloned-mac-address=stable\n",
+		},
+		expected: true,
+	}, {
+		message: "always",
+		files: map[string]string{
+			"/etc/NetworkManager/NetworkManager.conf": "[connection]\nwifi.mac-address-randomization=2\n",
+		},
+		expected: true,
+	}, {
+		message: "drop-in disables",
+		files: map[string]string{
+			"/etc/NetworkManager/NetworkManager.conf":  "[connection]\nwifi.cloned-mac-address=random\n",
+			"/etc/NetworkManager/conf.d/99-local.conf": "[connection]\nwifi.cloned-mac-address=permanent\n",
+		},
+		expected: false,
+	}, {
+		message: "scan randomization only",
+		files: map[string]string{
+			"/etc/NetworkManager/NetworkManager.conf": "[device]\nwifi.scan-rand-mac-address=yes\n",
+		},
+		expected: false,
+	}, {
+		message:  "no NetworkManager",
+		files:    map[string]string{},
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		h := &series.Host{Root: makeHostRoot(c, test.files)}
+
+		enabled, err := h.MACRandomizationEnabled()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(enabled, gc.Equals, test.expected)
+	}
+}
+
+func (s *networkSuite) TestMACRandomizationEnabledOverride(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.NetworkManagerConfigFile, "[connection]\nwifi.cloned-mac-address=random\n")
+	s.PatchValue(series.NetworkManagerConfigDir, filepath.Join(c.MkDir(), "conf.d"))
+
+	enabled, err := series.MACRandomizationEnabled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsTrue)
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package series_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+func (s *networkSuite) TestResolvConfMode(c *gc.C) {
+	for i, test := range []struct {
+		target   string
+		expected string
+	}{
+		{"../run/systemd/resolve/stub-resolv.conf", "systemd-resolved"},
+		{"/run/systemd/resolve/resolv.conf", "systemd-resolved"},
+		{"../run/resolvconf/resolv.conf", "resolvconf"},
+		{"/etc/resolvconf/run/resolv.conf", "resolvconf"},
+		{"/var/run/NetworkManager/resolv.conf", "static"},
+	} {
+		c.Logf("%d: %s", i, test.target)
+		filename := filepath.Join(c.MkDir(), "resolv.conf")
+		err := os.Symlink(test.target, filename)
+		c.Assert(err, jc.ErrorIsNil)
+		s.PatchValue(series.ResolvConfFile, filename)
+
+		mode, err := series.ResolvConfMode()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(mode, gc.Equals, test.expected)
+	}
+}
+
+func (s *networkSuite) TestResolvConfModeStatic(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "resolv.conf")
+	err := ioutil.WriteFile(filename, []byte("nameserver 192.0.2.53\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.ResolvConfFile, filename)
+
+	mode, err := series.ResolvConfMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mode, gc.Equals, "static")
+}
+
+func (s *networkSuite) TestResolvConfModeMissing(c *gc.C) {
+	s.PatchValue(series.ResolvConfFile, filepath.Join(c.MkDir(), "resolv.conf"))
+
+	_, err := series.ResolvConfMode()
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
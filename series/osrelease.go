@@ -0,0 +1,295 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os/v2"
+)
+
+var (
+	// ErrMissingID is returned when an os-release file has no ID, so the
+	// distribution it describes can't be identified.
+	ErrMissingID = errors.New("OS release file is missing ID")
+
+	// ErrUnknownSeries is returned when the os-release values identify a
+	// distribution, but not a release of it that has a known series.
+	ErrUnknownSeries = errors.New("could not determine series")
+)
+
+// ReadReleaseInfo returns all the values in the host's os-release file, such
+// as PRETTY_NAME, HOME_URL and BUILD_ID, with any quotes removed. The values
+// are parsed in the same way as they are to determine the host's series.
+func ReadReleaseInfo() (map[string]string, error) {
+	return defaultHost.ReadReleaseInfo()
+}
+
+// ReadSeriesFromReader returns the series identified by the os-release
+// contents read from r, such as a copy of a remote machine's
+// /etc/os-release. ErrMissingID and ErrUnknownSeries are returned as they
+// are, so that callers can compare them with errors.Is.
+func ReadSeriesFromReader(r io.Reader) (string, error) {
+	contents, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "unknown", errors.Trace(err)
+	}
+	values, err := parseOSRelease(string(contents))
+	if err != nil {
+		return "unknown", err
+	}
+	return seriesFromReleaseValues(values)
+}
+
+// seriesFromReleaseValues returns the series identified by the os-release
+// values, updating the series versions from distro-info if needed.
+func seriesFromReleaseValues(values map[string]string) (string, error) {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	updateSeriesVersionsOnce()
+	return seriesFromOSRelease(values)
+}
+
+// parseOSRelease parses the contents of an os-release file into its values,
+// which must include an ID.
+func parseOSRelease(contents string) (map[string]string, error) {
+	values := parseReleaseValues(contents)
+	if _, ok := values["ID"]; !ok {
+		return nil, ErrMissingID
+	}
+	return values, nil
+}
+
+// parseLSBRelease parses the contents of an lsb-release file, such as
+// "DISTRIB_ID=Ubuntu", into the os-release values that identify the series.
+func parseLSBRelease(contents string) (map[string]string, error) {
+	lsbValues := parseReleaseValues(contents)
+	if lsbValues["DISTRIB_ID"] == "" {
+		return nil, errors.New("LSB release file is missing DISTRIB_ID")
+	}
+	values := map[string]string{
+		"ID":         strings.ToLower(lsbValues["DISTRIB_ID"]),
+		"VERSION_ID": lsbValues["DISTRIB_RELEASE"],
+	}
+	if codename := lsbValues["DISTRIB_CODENAME"]; codename != "" {
+		values["VERSION_CODENAME"] = codename
+	}
+	return values, nil
+}
+
+// utf8BOM is the byte order mark that some images write at the start of
+// their release files.
+const utf8BOM = "\ufeff"
+
+// parseReleaseValues parses the KEY=value lines of a release file. Blank
+// lines, comments and lines without a "=" are ignored, and whitespace is
+// trimmed from around both the keys and values.
+func parseReleaseValues(contents string) map[string]string {
+	// Otherwise the first key would start with the byte order mark.
+	contents = strings.TrimPrefix(contents, utf8BOM)
+	values := make(map[string]string)
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		c := strings.SplitN(line, "=", 2)
+		if len(c) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(c[0])
+		if key == "" {
+			continue
+		}
+		values[key] = parseReleaseValue(c[1])
+	}
+	return values
+}
+
+// parseReleaseValue returns the value of a release file line, such as
+// "\"22.04\"" or "jammy # comment", without its quotes or any trailing
+// comment. As in the shell, a comment only starts at a "#" that follows
+// whitespace outside of the quotes.
+func parseReleaseValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			value = strings.TrimSpace(value[:i])
+			break
+		}
+	}
+	// An unmatched quote is dropped.
+	return strings.Trim(value, "'\"")
+}
+
+// The os-release IDs of the CentOS compatible rebuilds of RHEL.
+const (
+	rockyID     = "rocky"
+	almaLinuxID = "almalinux"
+)
+
+// The os-release IDs of the openSUSE family distributions that aren't
+// identified by the openSUSE ID.
+const (
+	slesID       = "sles"
+	tumbleweedID = "opensuse-tumbleweed"
+)
+
+// The os-release IDs of the RHEL family distributions that have series of
+// their own.
+const (
+	amazonLinuxID = "amzn"
+	oracleLinuxID = "ol"
+)
+
+// fedoraID is the os-release ID of Fedora, which RHEL and its rebuilds name
+// in their ID_LIKE.
+const fedoraID = "fedora"
+
+// platformEL matches the os-release PLATFORM_ID of a RHEL family release,
+// such as "platform:el9", giving its major version.
+var platformEL = regexp.MustCompile(`^platform:el([0-9]+)$`)
+
+// seriesFromOSRelease returns the series identified by the os-release
+// values. Distributions unknown to this package are identified by the first
+// distribution in their ID_LIKE that resolves to a series, such as Ubuntu
+// for Linux Mint, or are otherwise generic Linux. The caller must hold the
+// seriesVersionsMutex.
+func seriesFromOSRelease(values map[string]string) (string, error) {
+	if knownOSReleaseID(values["ID"]) {
+		return seriesFromOSReleaseID(values["ID"], values)
+	}
+	for _, id := range strings.Fields(values["ID_LIKE"]) {
+		if id == fedoraID {
+			// Fedora has no series, but RHEL family releases built from it
+			// name their Enterprise Linux version, and are given the CentOS
+			// series of it.
+			if match := platformEL.FindStringSubmatch(values["PLATFORM_ID"]); match != nil {
+				if series, err := getValue(centosSeries, strings.ToLower(jujuos.CentOS.String())+match[1]); err == nil {
+					return series, nil
+				}
+			}
+			continue
+		}
+		if !knownOSReleaseID(id) {
+			continue
+		}
+		if series, err := seriesFromOSReleaseID(id, values); err == nil {
+			return series, nil
+		}
+	}
+	return genericLinuxSeries, nil
+}
+
+// seriesFromOSReleaseID returns the series identified by the os-release
+// values, treating them as a release of the distribution with the id.
+func seriesFromOSReleaseID(id string, values map[string]string) (string, error) {
+	switch id {
+	case strings.ToLower(jujuos.Ubuntu.String()):
+		// Modern releases name their codename directly, which also works
+		// for releases that are missing from the version table.
+		if codename := ubuntuCodename(values); codename != "" {
+			return codename, nil
+		}
+		return getValueFromSeriesVersion(ubuntuSeries, values["VERSION_ID"])
+	case strings.ToLower(jujuos.CentOS.String()):
+		codename := fmt.Sprintf("%s%s", id, values["VERSION_ID"])
+		return getValue(centosSeries, codename)
+	case rockyID, almaLinuxID:
+		// The CentOS compatible rebuilds are given the CentOS series of
+		// their major version, so that both 9.2 and 9.4 are centos9.
+		major := strings.Split(values["VERSION_ID"], ".")[0]
+		return getValue(centosSeries, strings.ToLower(jujuos.CentOS.String())+major)
+	case amazonLinuxID:
+		// Amazon Linux versions are either 2 or, since Amazon Linux 2023,
+		// the year of the release, without a minor version.
+		return getValue(amazonLinuxSeries, amazonLinuxID+values["VERSION_ID"])
+	case oracleLinuxID:
+		// The minor version is dropped, so that 9.3 is oracle9.
+		major := strings.Split(values["VERSION_ID"], ".")[0]
+		return getValue(oracleLinuxSeries, "oracle"+major)
+	case strings.ToLower(jujuos.OpenSUSE.String()):
+		codename := fmt.Sprintf("%s%s",
+			id,
+			strings.Split(values["VERSION_ID"], ".")[0])
+		return getValue(opensuseSeries, codename)
+	case tumbleweedID:
+		// Tumbleweed is a rolling release with a date as its version, so
+		// there is only the one series.
+		return getValue(opensuseSeries, "opensusetumbleweed")
+	case slesID:
+		// Service packs are dropped, so that 15.5 is sles15.
+		major := strings.Split(values["VERSION_ID"], ".")[0]
+		return getValue(opensuseSeries, slesID+major)
+	case strings.ToLower(jujuos.Debian.String()):
+		codename := fmt.Sprintf("%s%s", id, values["VERSION_ID"])
+		return getValue(debianSeries, codename)
+	case strings.ToLower(jujuos.Alpine.String()):
+		// The patch level is dropped, so that 3.18.4 is alpine3.18.
+		version := strings.SplitN(values["VERSION_ID"], ".", 3)
+		if len(version) > 2 {
+			version = version[:2]
+		}
+		codename := fmt.Sprintf("%s%s", id, strings.Join(version, "."))
+		return getValue(alpineSeries, codename)
+	default:
+		return genericLinuxSeries, nil
+	}
+}
+
+// ubuntuCodename returns the Ubuntu codename given by the os-release values,
+// or "" if there isn't one.
+func ubuntuCodename(values map[string]string) string {
+	if codename := values["UBUNTU_CODENAME"]; codename != "" {
+		return codename
+	}
+	// Derivatives, such as Linux Mint, give their own codename here.
+	if values["ID"] != strings.ToLower(jujuos.Ubuntu.String()) {
+		return ""
+	}
+	return values["VERSION_CODENAME"]
+}
+
+// knownOSReleaseID returns whether the os-release ID is one that
+// seriesFromOSRelease resolves natively.
+func knownOSReleaseID(id string) bool {
+	switch id {
+	case rockyID, almaLinuxID, amazonLinuxID, oracleLinuxID, slesID, tumbleweedID:
+		return true
+	}
+	for _, osType := range []jujuos.OSType{jujuos.Ubuntu, jujuos.CentOS, jujuos.OpenSUSE, jujuos.Debian, jujuos.Alpine} {
+		if id == strings.ToLower(osType.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func getValue(from map[string]string, val string) (string, error) {
+	for serie, ver := range from {
+		if ver == val {
+			return serie, nil
+		}
+	}
+	return "unknown", ErrUnknownSeries
+}
+
+func getValueFromSeriesVersion(from map[string]SeriesVersionInfo, val string) (string, error) {
+	for s, version := range from {
+		if version.Version == val {
+			return s, nil
+		}
+	}
+	return "unknown", ErrUnknownSeries
+}
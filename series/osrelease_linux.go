@@ -0,0 +1,42 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/errors"
+)
+
+// ReadReleaseInfo returns all the values in the host's os-release file.
+func (h *Host) ReadReleaseInfo() (map[string]string, error) {
+	values, err := h.readOSRelease()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return values, nil
+}
+
+// readOSRelease reads and parses the host's os-release file, falling back
+// to the copy in /usr/lib if it is missing or has no ID. Errors reading the
+// file are returned as is, so that a missing file can be detected with
+// os.IsNotExist.
+func (h *Host) readOSRelease() (map[string]string, error) {
+	values, err := readOSReleaseFile(h.path(osReleaseFile))
+	if err == nil {
+		return values, nil
+	}
+	if values, fallbackErr := readOSReleaseFile(h.path(usrLibOSReleaseFile)); fallbackErr == nil {
+		return values, nil
+	}
+	return nil, err
+}
+
+func readOSReleaseFile(path string) (map[string]string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseOSRelease(string(contents))
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	stderrors "errors"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+func (s *osReleaseSuite) TestReadReleaseInfo(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.OSReleaseFile, `PRETTY_NAME="Ubuntu 22.04.3 LTS"
+NAME="Ubuntu"
+VERSION_ID= "22.04" 
+ID=ubuntu
+HOME_URL='https://www.ubuntu.com/'
+BUILD_ID=20231010
+not a value
+`)
+
+	values, err := series.ReadReleaseInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values, jc.DeepEquals, map[string]string{
+		"PRETTY_NAME": "Ubuntu 22.04.3 LTS",
+		"NAME":        "Ubuntu",
+		"VERSION_ID":  "22.04",
+		"ID":          "ubuntu",
+		"HOME_URL":    "https://www.ubuntu.com/",
+		"BUILD_ID":    "20231010",
+	})
+}
+
+func (s *osReleaseSuite) TestReadReleaseInfoMissingID(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.OSReleaseFile, "NAME=\"Ubuntu\"\n")
+	s.PatchValue(series.UsrLibOSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+
+	_, err := series.ReadReleaseInfo()
+	c.Assert(err, gc.ErrorMatches, "OS release file is missing ID")
+}
+
+func (s *osReleaseSuite) TestReadSeriesErrorIs(c *gc.C) {
+	s.PatchValue(series.UsrLibOSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+	s.PatchValue(series.LSBReleaseFile, filepath.Join(c.MkDir(), "lsb-release"))
+
+	patchFile(c, &s.CleanupSuite, series.OSReleaseFile, "NAME=\"Ubuntu\"\n")
+	_, err := series.ReadSeries()
+	c.Check(stderrors.Is(err, series.ErrMissingID), jc.IsTrue)
+
+	patchFile(c, &s.CleanupSuite, series.OSReleaseFile, "ID=debian\nVERSION_ID=\"7\"\n")
+	_, err = series.ReadSeries()
+	c.Check(stderrors.Is(err, series.ErrUnknownSeries), jc.IsTrue)
+
+	// Annotated errors are matched by their cause.
+	_, err = series.NewHost().Series()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: could not determine series")
+	c.Check(errors.Cause(err), gc.Equals, series.ErrUnknownSeries)
+}
+
+func (s *osReleaseSuite) TestReadReleaseInfoMissingFile(c *gc.C) {
+	s.PatchValue(series.OSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+	s.PatchValue(series.UsrLibOSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+
+	_, err := series.ReadReleaseInfo()
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
+
+func (s *osReleaseSuite) TestReadReleaseInfoUsrLib(c *gc.C) {
+	for i, etc := range []string{"", "NAME=\"Fedora Linux\"\n"} {
+		c.Logf("%d: %q", i, etc)
+		if etc == "" {
+			s.PatchValue(series.OSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+		} else {
+			patchFile(c, &s.CleanupSuite, series.OSReleaseFile, etc)
+		}
+		patchFile(c, &s.CleanupSuite, series.UsrLibOSReleaseFile, "ID=fedora\nVERSION_ID=39\n")
+
+		values, err := series.ReadReleaseInfo()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(values["ID"], gc.Equals, "fedora")
+	}
+}
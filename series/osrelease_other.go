@@ -0,0 +1,16 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+// ReadReleaseInfo is only supported on Linux.
+func (h *Host) ReadReleaseInfo() (map[string]string, error) {
+	return nil, errors.NotSupportedf("os-release")
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	stderrors "errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type osReleaseSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&osReleaseSuite{})
+
+func (s *osReleaseSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+}
+
+func (s *osReleaseSuite) TestReadSeriesFromReader(c *gc.C) {
+	for i, test := range []struct {
+		contents string
+		series   string
+		err      string
+	}{{
+		contents: "NAME=\"Ubuntu\"\nID=ubuntu\nVERSION_ID=\"22.04\"\n",
+		series:   "jammy",
+	}, {
+		contents: "NAME=\"CentOS Linux\"\nID=\"centos\"\nVERSION_ID=\"7\"\n",
+		series:   "centos7",
+	}, {
+		contents: "NAME=\"Linux Mint\"\nID=linuxmint\nID_LIKE=\"ubuntu debian\"\nVERSION_ID=\"21.2\"\nUBUNTU_CODENAME=jammy\n",
+		series:   "jammy",
+	}, {
+		contents: "NAME=\"Arch Linux\"\nID=arch\n",
+		series:   "genericlinux",
+	}, {
+		contents: "\n# Ubuntu\nNAME=\"Ubuntu\"\n\tID = ubuntu # comment\n  VERSION_ID=\t'20.04'\t\n",
+		series:   "focal",
+	}, {
+		contents: "\xef\xbb\xbfID=ubuntu\nVERSION_ID=\"22.04\"\n",
+		series:   "jammy",
+	}, {
+		contents: "NAME=\"Ubuntu\"\n",
+		series:   "unknown",
+		err:      "OS release file is missing ID",
+	}} {
+		c.Logf("%d: %q", i, test.contents)
+		result, err := series.ReadSeriesFromReader(strings.NewReader(test.contents))
+		if test.err == "" {
+			c.Assert(err, jc.ErrorIsNil)
+		} else {
+			c.Assert(err, gc.ErrorMatches, test.err)
+		}
+		c.Check(result, gc.Equals, test.series)
+	}
+}
+
+func (s *osReleaseSuite) TestParseReleaseValues(c *gc.C) {
+	values := series.ParseReleaseValues(`# Comment
+NAME="Ubuntu"
+
+VERSION_ID= "22.04" 
+	ID	=	ubuntu	
+ID_LIKE=debian # comment
+VERSION_CODENAME='jammy' # comment
+PRETTY_NAME="Ubuntu # 22.04"
+HOME_URL=https://www.ubuntu.com/#home
+BUG_REPORT_URL="https://bugs.launchpad.net/ubuntu/
+garbage
+=orphan
+`)
+	c.Check(values, jc.DeepEquals, map[string]string{
+		"NAME":             "Ubuntu",
+		"VERSION_ID":       "22.04",
+		"ID":               "ubuntu",
+		"ID_LIKE":          "debian",
+		"VERSION_CODENAME": "jammy",
+		"PRETTY_NAME":      "Ubuntu # 22.04",
+		"HOME_URL":         "https://www.ubuntu.com/#home",
+		"BUG_REPORT_URL":   "https://bugs.launchpad.net/ubuntu/",
+	})
+}
+
+func (s *osReleaseSuite) TestReadSeriesFromReaderErrorIs(c *gc.C) {
+	_, err := series.ReadSeriesFromReader(strings.NewReader("NAME=\"Ubuntu\"\n"))
+	c.Assert(err, gc.ErrorMatches, "OS release file is missing ID")
+	c.Check(stderrors.Is(err, series.ErrMissingID), jc.IsTrue)
+	c.Check(stderrors.Is(err, series.ErrUnknownSeries), jc.IsFalse)
+
+	_, err = series.ReadSeriesFromReader(strings.NewReader("ID=centos\nVERSION_ID=\"5\"\n"))
+	c.Assert(err, gc.ErrorMatches, "could not determine series")
+	c.Check(stderrors.Is(err, series.ErrUnknownSeries), jc.IsTrue)
+	c.Check(stderrors.Is(err, series.ErrMissingID), jc.IsFalse)
+}
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func (s *osReleaseSuite) TestReadSeriesFromReaderError(c *gc.C) {
+	result, err := series.ReadSeriesFromReader(errorReader{})
+	c.Assert(err, gc.ErrorMatches, "connection reset")
+	c.Check(result, gc.Equals, "unknown")
+}
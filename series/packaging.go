@@ -0,0 +1,111 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// packageManagerBinaries holds, for each OS, the groups of binaries that make
+// up its package manager. At least one binary from every group must be
+// present for the package manager to be usable.
+var packageManagerBinaries = map[os.OSType][][]string{
+	os.Ubuntu:   {{"apt-get"}, {"dpkg"}},
+	os.CentOS:   {{"dnf", "yum"}, {"rpm"}},
+	os.OpenSUSE: {{"zypper"}, {"rpm"}},
+	os.Debian:   {{"apt-get"}, {"dpkg"}},
+	os.Alpine:   {{"apk"}},
+}
+
+// PackageManagerPresent returns true if the binaries of the package manager
+// used by the OS are found in PATH. Container images sometimes ship without
+// them, in which case no package commands can be run.
+func PackageManagerPresent(osType os.OSType) (bool, error) {
+	return defaultHost.PackageManagerPresent(osType)
+}
+
+// PackageManagerPresent returns true if the binaries of the package manager
+// used by the OS are found in the host's PATH. The lookup is made with
+// "command -v" through the host's CommandRunner.
+func (h *Host) PackageManagerPresent(osType os.OSType) (bool, error) {
+	groups, ok := packageManagerBinaries[osType]
+	if !ok {
+		return false, errors.NotSupportedf("package manager for %s", osType)
+	}
+	for _, group := range groups {
+		var found bool
+		for _, binary := range group {
+			if h.commandExists(binary) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// commandExists returns true if the named command is found in the host's
+// PATH.
+func (h *Host) commandExists(name string) bool {
+	_, err := h.runner().Run("sh", "-c", "command -v "+name)
+	return err == nil
+}
+
+// packagingFamilies maps each OS to the format of the packages it installs.
+var packagingFamilies = map[os.OSType]string{
+	os.Ubuntu:   "deb",
+	os.CentOS:   "rpm",
+	os.OpenSUSE: "rpm",
+	os.Debian:   "deb",
+}
+
+// PackagingFamily returns the format of the packages installed on the
+// specified series: "deb", "rpm", or "other" for operating systems that use
+// neither, such as OSX and Windows.
+func PackagingFamily(series string) (string, error) {
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if family, ok := packagingFamilies[osType]; ok {
+		return family, nil
+	}
+	return "other", nil
+}
+
+// archiveSigningKeyIDs maps each OS to the long ID of the key that signs its
+// package archive.
+var archiveSigningKeyIDs = map[os.OSType]string{
+	// Ubuntu Archive Automatic Signing Key (2018).
+	os.Ubuntu: "871920D1991BC93C",
+	// CentOS (CentOS Official Signing Key), used from CentOS 8 onwards.
+	os.CentOS: "05B555B38483C65D",
+}
+
+// ArchiveSigningKeyID returns the long ID of the GPG key that signs the
+// package archive of the OS. A NotFound error is returned for operating
+// systems without a well known archive key.
+func ArchiveSigningKeyID(osType os.OSType) (string, error) {
+	if keyID, ok := archiveSigningKeyIDs[osType]; ok {
+		return keyID, nil
+	}
+	return "", errors.NotFoundf("archive signing key for %s", osType)
+}
+
+// VersionLockMechanism returns the tool used to pin package versions on the
+// OS, and the path of the configuration that pins are written to: apt
+// preferences for Ubuntu, and the yum versionlock plugin for CentOS.
+func VersionLockMechanism(osType os.OSType) (tool string, configPath string, err error) {
+	switch osType {
+	case os.Ubuntu:
+		return "apt", "/etc/apt/preferences.d/", nil
+	case os.CentOS:
+		return "versionlock", "/etc/yum/pluginconf.d/versionlock.list", nil
+	}
+	return "", "", errors.NotSupportedf("version locking on %s", osType)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type packagingSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&packagingSuite{})
+
+func (s *packagingSuite) patchPath(binaries ...string) *fakeRunner {
+	runner := &fakeRunner{output: make(map[string]string)}
+	for _, binary := range binaries {
+		runner.output["sh -c command -v "+binary] = "/usr/bin/" + binary + "\n"
+	}
+	restore := series.SetCommandRunner(runner)
+	s.AddCleanup(func(*gc.C) { restore() })
+	return runner
+}
+
+func (s *packagingSuite) TestPackageManagerPresent(c *gc.C) {
+	for i, test := range []struct {
+		os       os.OSType
+		binaries []string
+		expected bool
+	}{
+		{os.Ubuntu, []string{"apt-get", "dpkg"}, true},
+		{os.Ubuntu, []string{"dpkg"}, false},
+		{os.Ubuntu, nil, false},
+		{os.CentOS, []string{"yum", "rpm"}, true},
+		{os.CentOS, []string{"dnf", "rpm"}, true},
+		{os.CentOS, []string{"rpm"}, false},
+		{os.OpenSUSE, []string{"zypper", "rpm"}, true},
+		{os.OpenSUSE, []string{"rpm"}, false},
+	} {
+		c.Logf("%d: %s %v", i, test.os, test.binaries)
+		s.patchPath(test.binaries...)
+
+		present, err := series.PackageManagerPresent(test.os)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(present, gc.Equals, test.expected)
+	}
+}
+
+func (s *packagingSuite) TestPackageManagerPresentHostRunner(c *gc.C) {
+	runner := &fakeRunner{output: map[string]string{
+		"sh -c command -v apt-get": "/usr/bin/apt-get\n",
+		"sh -c command -v dpkg":    "/usr/bin/dpkg\n",
+	}}
+	h := &series.Host{Runner: runner}
+
+	present, err := h.PackageManagerPresent(os.Ubuntu)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(present, jc.IsTrue)
+	c.Check(runner.calls, jc.DeepEquals, [][]string{
+		{"sh", "-c", "command -v apt-get"},
+		{"sh", "-c", "command -v dpkg"},
+	})
+}
+
+func (s *packagingSuite) TestPackageManagerPresentNotSupported(c *gc.C) {
+	_, err := series.PackageManagerPresent(os.Windows)
+	c.Assert(err, gc.ErrorMatches, "package manager for Windows not supported")
+}
+
+func (s *packagingSuite) TestPackagingFamily(c *gc.C) {
+	for _, test := range []struct {
+		series   string
+		expected string
+	}{
+		{"jammy", "deb"},
+		{"focal", "deb"},
+		{"centos7", "rpm"},
+		{"opensuseleap", "rpm"},
+		{"sonoma", "other"},
+		{"win2019", "other"},
+		{"kubernetes", "other"},
+	} {
+		family, err := series.PackagingFamily(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(family, gc.Equals, test.expected, gc.Commentf("%s", test.series))
+	}
+}
+
+func (s *packagingSuite) TestPackagingFamilyUnknownSeries(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	_, err := series.PackagingFamily("firewolf")
+	c.Assert(err, gc.ErrorMatches, `unknown OS for series: "firewolf"`)
+}
+
+func (s *packagingSuite) TestArchiveSigningKeyID(c *gc.C) {
+	keyID, err := series.ArchiveSigningKeyID(os.Ubuntu)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keyID, gc.Equals, "871920D1991BC93C")
+
+	keyID, err = series.ArchiveSigningKeyID(os.CentOS)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keyID, gc.Equals, "05B555B38483C65D")
+}
+
+func (s *packagingSuite) TestArchiveSigningKeyIDUnknown(c *gc.C) {
+	_, err := series.ArchiveSigningKeyID(os.Windows)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "archive signing key for Windows not found")
+}
+
+func (s *packagingSuite) TestVersionLockMechanism(c *gc.C) {
+	tool, configPath, err := series.VersionLockMechanism(os.Ubuntu)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tool, gc.Equals, "apt")
+	c.Check(configPath, gc.Equals, "/etc/apt/preferences.d/")
+
+	tool, configPath, err = series.VersionLockMechanism(os.CentOS)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tool, gc.Equals, "versionlock")
+	c.Check(configPath, gc.Equals, "/etc/yum/pluginconf.d/versionlock.list")
+}
+
+func (s *packagingSuite) TestVersionLockMechanismNotSupported(c *gc.C) {
+	_, _, err := series.VersionLockMechanism(os.Windows)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "version locking on Windows not supported")
+}
@@ -0,0 +1,16 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package series
+
+import (
+	"github.com/juju/errors"
+)
+
+// ResourceLimits is a function that has no meaning except on Linux and OSX.
+func ResourceLimits() (nofile uint64, nproc uint64, err error) {
+	return 0, 0, errors.NotSupportedf("resource limits")
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package series
+
+import (
+	"github.com/juju/errors"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	// getrlimit reads a resource limit (overrideable for testing).
+	getrlimit = unix.Getrlimit
+)
+
+// ResourceLimits returns the soft limits on the number of open files and
+// the number of processes for the current process.
+func ResourceLimits() (nofile uint64, nproc uint64, err error) {
+	var limit unix.Rlimit
+	if err := getrlimit(unix.RLIMIT_NOFILE, &limit); err != nil {
+		return 0, 0, errors.Annotate(err, "reading open file limit")
+	}
+	nofile = limit.Cur
+	if err := getrlimit(unix.RLIMIT_NPROC, &limit); err != nil {
+		return 0, 0, errors.Annotate(err, "reading process limit")
+	}
+	return nofile, limit.Cur, nil
+}
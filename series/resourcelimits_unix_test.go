@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package series_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/sys/unix"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type resourceLimitsSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&resourceLimitsSuite{})
+
+func (s *resourceLimitsSuite) TestResourceLimits(c *gc.C) {
+	s.PatchValue(series.Getrlimit, func(resource int, limit *unix.Rlimit) error {
+		switch resource {
+		case unix.RLIMIT_NOFILE:
+			*limit = unix.Rlimit{Cur: 1024, Max: 1048576}
+		case unix.RLIMIT_NPROC:
+			*limit = unix.Rlimit{Cur: 63304, Max: 63304}
+		default:
+			c.Fatalf("unexpected resource %d", resource)
+		}
+		return nil
+	})
+
+	nofile, nproc, err := series.ResourceLimits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(nofile, gc.Equals, uint64(1024))
+	c.Check(nproc, gc.Equals, uint64(63304))
+}
+
+func (s *resourceLimitsSuite) TestResourceLimitsError(c *gc.C) {
+	s.PatchValue(series.Getrlimit, func(resource int, limit *unix.Rlimit) error {
+		return errors.New("boom")
+	})
+
+	_, _, err := series.ResourceLimits()
+	c.Assert(err, gc.ErrorMatches, "reading open file limit: boom")
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// CommandRunner defines an interface for running commands on a host. All
+// detection that shells out goes through the package CommandRunner, so that
+// it can be faked in tests or run against a remote machine.
+type CommandRunner interface {
+	// Run runs the named command with the given arguments and returns its
+	// standard output.
+	Run(name string, args ...string) (string, error)
+}
+
+// commandRunner is the CommandRunner used by the package, overrideable via
+// SetCommandRunner.
+var commandRunner CommandRunner = execRunner{}
+
+// SetCommandRunner sets the CommandRunner used to run commands. It returns a
+// function that restores the previous CommandRunner.
+func SetCommandRunner(r CommandRunner) func() {
+	old := commandRunner
+	commandRunner = r
+	return func() {
+		commandRunner = old
+	}
+}
+
+// execRunner implements the CommandRunner using os/exec.
+type execRunner struct{}
+
+func (execRunner) Run(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return "", errors.Annotatef(err, "running %s", name)
+	}
+	return string(out), nil
+}
+
+// unameRelease returns the kernel release reported by "uname -r".
+func (h *Host) unameRelease() (string, error) {
+	out, err := h.runner().Run("uname", "-r")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSpace(out), nil
+}
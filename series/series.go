@@ -6,6 +6,7 @@
 package series
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,10 +29,22 @@ var (
 	// MustHostSeries calls HostSeries and panics if there is an error.
 	MustHostSeries = mustHostSeries
 
-	seriesOnce sync.Once
-	// These are filled in by the first call to hostSeries
-	series    string
-	seriesErr error
+	// These are filled in by the first call to hostSeries, and cleared by
+	// ResetHostSeriesCache.
+	seriesMutex  sync.Mutex
+	seriesCached bool
+	series       string
+	seriesErr    error
+
+	// These are filled in by the first call to HostReleaseVersion, and
+	// cleared by ResetHostSeriesCache.
+	releaseVersionCached bool
+	releaseVersion       string
+	releaseVersionErr    error
+
+	// readHostSeries reads the series of the machine the current process
+	// is running on (overrideable for testing).
+	readHostSeries = readSeries
 
 	// timeNow is time.Now, but overrideable via TimeNow in tests.
 	timeNow = time.Now
@@ -40,16 +53,91 @@ var (
 // hostSeries returns the series of the machine the current process is
 // running on.
 func hostSeries() (string, error) {
-	var err error
-	seriesOnce.Do(func() {
-		series, err = readSeries()
+	return HostSeriesContext(context.Background())
+}
+
+// HostSeriesContext returns the series of the machine the current process
+// is running on, as HostSeries does, unless the context is done first, in
+// which case the context's error is returned. Detection that is abandoned
+// carries on in the background, and its result is cached for later calls.
+func HostSeriesContext(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	type result struct {
+		series string
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		series, err := cachedHostSeries()
+		done <- result{series, err}
+	}()
+	select {
+	case r := <-done:
+		return r.series, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// cachedHostSeries returns the series of the machine the current process is
+// running on, reading it only if it isn't cached.
+func cachedHostSeries() (string, error) {
+	seriesMutex.Lock()
+	defer seriesMutex.Unlock()
+	if !seriesCached {
+		hostSeries, err := readHostSeries()
 		if err != nil {
-			seriesErr = errors.Annotate(err, "cannot determine host series")
+			err = errors.Annotate(err, "cannot determine host series")
 		}
-	})
+		series, seriesErr, seriesCached = hostSeries, err, true
+	}
 	return series, seriesErr
 }
 
+// HostReleaseVersion returns the version of the release of the machine the
+// current process is running on, such as "22.04" for Ubuntu, "2.14" for a
+// Linux distribution whose series is genericlinux, or "14.5" for macOS. As
+// with HostSeries, the result is cached.
+func HostReleaseVersion() (string, error) {
+	seriesMutex.Lock()
+	defer seriesMutex.Unlock()
+	if !releaseVersionCached {
+		version, err := defaultHost.ReleaseVersion()
+		if err != nil {
+			err = errors.Annotate(err, "cannot determine host release version")
+		}
+		releaseVersion, releaseVersionErr, releaseVersionCached = version, err, true
+	}
+	return releaseVersion, releaseVersionErr
+}
+
+// ResetHostSeriesCache discards the series cached by HostSeries, and the
+// version cached by HostReleaseVersion, whether they were read successfully
+// or not, so that the next call detects them again.
+func ResetHostSeriesCache() {
+	seriesMutex.Lock()
+	defer seriesMutex.Unlock()
+	series, seriesErr, seriesCached = "", nil, false
+	releaseVersion, releaseVersionErr, releaseVersionCached = "", nil, false
+}
+
+// HostOS returns the operating system of the machine the current process is
+// running on. It is detected from the series returned by HostSeries, so it
+// shares its cache; os.Unknown is returned if detection fails.
+func HostOS() (os.OSType, error) {
+	series, err := HostSeries()
+	if err != nil {
+		return os.Unknown, errors.Trace(err)
+	}
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return os.Unknown, errors.Trace(err)
+	}
+	return osType, nil
+}
+
 // mustHostSeries calls HostSeries and panics if there is an error.
 func mustHostSeries() string {
 	series, err := HostSeries()
@@ -71,16 +159,50 @@ func MustOSFromSeries(series string) os.OSType {
 
 // kernelToMajor takes a dotted version and returns just the Major portion
 func kernelToMajor(getKernelVersion func() (string, error)) (int, error) {
+	majorVersion, _, err := kernelToMajorMinor(getKernelVersion)
+	return majorVersion, err
+}
+
+// parseKernelRelease returns the major and minor version of a kernel
+// release, such as "5.15.0-91-generic" or "23.4.0", which must have at least
+// a major and minor version.
+func parseKernelRelease(release string) (int, int, error) {
+	major, minor, err := kernelToMajorMinor(func() (string, error) {
+		return release, nil
+	})
+	if err != nil || !strings.Contains(release, ".") {
+		return 0, 0, errors.NotValidf("kernel release %q", release)
+	}
+	return major, minor, nil
+}
+
+// kernelToMajorMinor takes a dotted version, such as "5.15.0-91-generic",
+// and returns the Major and Minor portions. A version without a Minor
+// portion has a Minor version of 0.
+func kernelToMajorMinor(getKernelVersion func() (string, error)) (int, int, error) {
 	fullVersion, err := getKernelVersion()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	parts := strings.SplitN(fullVersion, ".", 2)
+	parts := strings.SplitN(fullVersion, ".", 3)
 	majorVersion, err := strconv.ParseInt(parts[0], 10, 32)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return int(majorVersion), nil
+	if len(parts) == 1 {
+		return int(majorVersion), 0, nil
+	}
+	// The Minor version may be followed directly by a suffix, such as
+	// "4.19-rc1".
+	minor := parts[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minor = minor[:i]
+	}
+	minorVersion, err := strconv.ParseInt(minor, 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(majorVersion), int(minorVersion), nil
 }
 
 func macOSXSeriesFromKernelVersion(getKernelVersion func() (string, error)) (string, error) {
@@ -92,14 +214,22 @@ func macOSXSeriesFromKernelVersion(getKernelVersion func() (string, error)) (str
 	return macOSXSeriesFromMajorVersion(majorVersion)
 }
 
+// macOSXSeriesFromUname returns the Mac OSX series, using the kernel release
+// reported by uname.
+func macOSXSeriesFromUname() (string, error) {
+	return macOSXSeriesFromKernelVersion(defaultHost.unameRelease)
+}
+
 // TODO(jam): 2014-05-06 https://launchpad.net/bugs/1316593
 // we should have a system file that we can read so this can be updated without
 // recompiling Juju. For now, this is a lot easier, and also solves the fact
 // that we want to populate HostSeries during init() time, before
 // we've potentially read that information from anywhere else
 // macOSXSeries maps from the Darwin Kernel Major Version to the Mac OSX
-// series.
+// series. Since Big Sur (macOS 11, Darwin 20) the Darwin major version is the
+// macOS major version plus 9, so each new release is a single entry here.
 var macOSXSeries = map[int]string{
+	24: "sequoia",
 	23: "sonoma",
 	22: "ventura",
 	21: "monterey",
@@ -121,6 +251,69 @@ var macOSXSeries = map[int]string{
 	5:  "puma",
 }
 
+// macOSMajorSeries maps from the macOS major version to the Mac OSX series,
+// for the releases since Big Sur (macOS 11). Earlier releases were all 10.x.
+var macOSMajorSeries = map[int]string{
+	15: "sequoia",
+	14: "sonoma",
+	13: "ventura",
+	12: "monterey",
+	11: "bigsur",
+}
+
+// macOS10Series maps from the minor version of the macOS 10.x releases to
+// the Mac OSX series. Big Sur also reports itself as 10.16 to software built
+// for earlier releases.
+var macOS10Series = map[int]string{
+	16: "bigsur",
+	15: "catalina",
+	14: "mojave",
+	13: "highsierra",
+	12: "sierra",
+	11: "elcapitan",
+	10: "yosemite",
+	9:  "mavericks",
+	8:  "mountainlion",
+	7:  "lion",
+	6:  "snowleopard",
+	5:  "leopard",
+	4:  "tiger",
+	3:  "panther",
+	2:  "jaguar",
+	1:  "puma",
+}
+
+// MacOSSeriesFromProductVersion returns the Mac OSX series of the macOS
+// product version, such as "10.15.7" or "14.5", as reported by sw_vers.
+// Releases before Big Sur are identified by their 10.x version, and later
+// releases by their major version alone.
+func MacOSSeriesFromProductVersion(version string) (string, error) {
+	parts := strings.Split(version, ".")
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "unknown", errors.NotValidf("macOS version %q", version)
+	}
+	if major != 10 {
+		series, ok := macOSMajorSeries[major]
+		if !ok {
+			return "unknown", errors.NotFoundf("macOS series for version %q", version)
+		}
+		return series, nil
+	}
+	if len(parts) < 2 {
+		return "unknown", errors.NotValidf("macOS version %q", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "unknown", errors.NotValidf("macOS version %q", version)
+	}
+	series, ok := macOS10Series[minor]
+	if !ok {
+		return "unknown", errors.NotFoundf("macOS series for version %q", version)
+	}
+	return series, nil
+}
+
 func macOSXSeriesFromMajorVersion(majorVersion int) (string, error) {
 	series, ok := macOSXSeries[majorVersion]
 	if !ok {
@@ -4,7 +4,23 @@
 package series
 
 import (
+	"strings"
 	"syscall"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// swVersProductVersion returns the macOS product version, such as
+	// "14.5", reported by "sw_vers -productVersion" (overrideable for
+	// testing).
+	swVersProductVersion = func(h *Host) (string, error) {
+		out, err := h.runner().Run("sw_vers", "-productVersion")
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return strings.TrimSpace(out), nil
+	}
 )
 
 func sysctlVersion() (string, error) {
@@ -13,5 +29,37 @@ func sysctlVersion() (string, error) {
 
 // readSeries returns the best approximation to what version this machine is.
 func readSeries() (string, error) {
-	return macOSXSeriesFromKernelVersion(sysctlVersion)
+	return defaultHost.readSeries()
+}
+
+// ReleaseVersion returns the macOS product version of the host, such as
+// "14.5".
+func (h *Host) ReleaseVersion() (string, error) {
+	version, err := swVersProductVersion(h)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return version, nil
+}
+
+func (h *Host) readSeries() (string, error) {
+	// The product version names the release directly, whereas the kernel
+	// version has to be mapped onto it.
+	version, err := swVersProductVersion(h)
+	if err == nil {
+		series, err := MacOSSeriesFromProductVersion(version)
+		if err == nil {
+			return series, nil
+		}
+		logger.Debugf("unable to determine series from macOS version %q: %v", version, err)
+	} else {
+		logger.Debugf("unable to read macOS version: %v", err)
+	}
+
+	series, err := macOSXSeriesFromKernelVersion(sysctlVersion)
+	if err != nil {
+		// Fall back to asking uname for the kernel release.
+		return macOSXSeriesFromKernelVersion(h.unameRelease)
+	}
+	return series, nil
 }
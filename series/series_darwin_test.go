@@ -5,6 +5,8 @@ package series
 
 import (
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 )
@@ -29,3 +31,57 @@ func (s *macOSXSeriesSuite) TestOSVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(version, jc.Satisfies, knownSeries.Contains)
 }
+
+type productVersionSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&productVersionSuite{})
+
+func (s *productVersionSuite) TestReadSeriesFromProductVersion(c *gc.C) {
+	for i, test := range []struct {
+		version string
+		series  string
+	}{
+		{"10.15.7", "catalina"},
+		{"10.9", "mavericks"},
+		{"11.7", "bigsur"},
+		{"14.5", "sonoma"},
+		{"15.0", "sequoia"},
+	} {
+		c.Logf("%d: %s", i, test.version)
+		version := test.version
+		s.PatchValue(&swVersProductVersion, func(*Host) (string, error) {
+			return version, nil
+		})
+		series, err := defaultHost.readSeries()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(series, gc.Equals, test.series)
+	}
+}
+
+func (s *productVersionSuite) TestReadSeriesFallsBackToKernel(c *gc.C) {
+	knownSeries := make(set.Strings)
+	for _, series := range macOSXSeries {
+		knownSeries.Add(series)
+	}
+	for i, swVers := range []func(*Host) (string, error){
+		func(*Host) (string, error) { return "", errors.New("sw_vers not found") },
+		func(*Host) (string, error) { return "99.1", nil },
+	} {
+		c.Logf("%d", i)
+		s.PatchValue(&swVersProductVersion, swVers)
+		series, err := defaultHost.readSeries()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(series, jc.Satisfies, knownSeries.Contains)
+	}
+}
+
+func (s *productVersionSuite) TestReleaseVersion(c *gc.C) {
+	s.PatchValue(&swVersProductVersion, func(*Host) (string, error) {
+		return "14.5", nil
+	})
+	version, err := defaultHost.ReleaseVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "14.5")
+}
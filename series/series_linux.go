@@ -4,8 +4,9 @@
 package series
 
 import (
-	"fmt"
+	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
@@ -16,57 +17,200 @@ var (
 	// osReleaseFile is the name of the file that is read in order to determine
 	// the linux type release version.
 	osReleaseFile = "/etc/os-release"
+
+	// usrLibOSReleaseFile is read instead of osReleaseFile when that is
+	// missing or has no ID, as on some minimal and immutable images.
+	usrLibOSReleaseFile = "/usr/lib/os-release"
+
+	// lsbReleaseFile is read on hosts whose os-release file is missing or
+	// has no ID, such as some older and stripped down Ubuntu images.
+	lsbReleaseFile = "/etc/lsb-release"
+
+	// redhatReleaseFile is the name of the file that is read in order to
+	// determine the release of older RHEL family hosts that have no
+	// os-release file.
+	redhatReleaseFile = "/etc/redhat-release"
 )
 
 func readSeries() (string, error) {
-	values, err := jujuos.ReadOSRelease(osReleaseFile)
+	return defaultHost.readSeries()
+}
+
+func (h *Host) readSeries() (string, error) {
+	values, _, err := h.releaseValues()
 	if err != nil {
 		return "unknown", err
 	}
-	updateSeriesVersionsOnce()
-	return seriesFromOSRelease(values)
+	series, _, err := h.seriesFromValues(values)
+	return series, err
+}
+
+// DetectSeries returns the series of the host, along with how confident the
+// detection is. If the host has neither an os-release nor a redhat-release
+// file, the series is guessed from the kernel release.
+func (h *Host) DetectSeries() (string, Confidence, error) {
+	values, confidence, err := h.releaseValues()
+	if os.IsNotExist(err) {
+		series, err := h.seriesFromKernelRelease()
+		if err != nil {
+			return "unknown", Guessed, errors.Annotate(err, "cannot determine host series")
+		}
+		return series, Guessed, nil
+	} else if err != nil {
+		return "unknown", confidence, errors.Annotate(err, "cannot determine host series")
+	}
+	series, seriesConfidence, err := h.seriesFromValues(values)
+	if err != nil {
+		return series, confidence, errors.Annotate(err, "cannot determine host series")
+	}
+	if seriesConfidence > confidence {
+		confidence = seriesConfidence
+	}
+	return series, confidence, nil
+}
+
+// releaseValues returns the os-release values that identify the host's
+// release. Hosts without a usable os-release file have the values derived
+// from their lsb-release or redhat-release file instead.
+func (h *Host) releaseValues() (map[string]string, Confidence, error) {
+	values, err := h.readOSRelease()
+	if err == nil {
+		return values, Exact, nil
+	}
+	if lsbValues, lsbErr := readLSBRelease(h.path(lsbReleaseFile)); lsbErr == nil {
+		return lsbValues, Derived, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, Exact, err
+	}
+	values, err = readRedhatRelease(h.path(redhatReleaseFile))
+	return values, Derived, err
+}
+
+// ReleaseVersion returns the version of the host's release, such as "22.04"
+// or "9", as given by its VERSION_ID. The version is returned whether or not
+// the series of the release is known.
+func (h *Host) ReleaseVersion() (string, error) {
+	values, _, err := h.releaseValues()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	version := values["VERSION_ID"]
+	if version == "" {
+		return "", errors.NotFoundf("VERSION_ID")
+	}
+	return version, nil
 }
 
-func seriesFromOSRelease(values map[string]string) (string, error) {
-	switch values["ID"] {
-	case strings.ToLower(jujuos.Ubuntu.String()):
-		return getValueFromSeriesVersion(ubuntuSeries, values["VERSION_ID"])
-	case strings.ToLower(jujuos.CentOS.String()):
-		codename := fmt.Sprintf("%s%s", values["ID"], values["VERSION_ID"])
-		return getValue(centosSeries, codename)
-	case strings.ToLower(jujuos.OpenSUSE.String()):
-		codename := fmt.Sprintf("%s%s",
-			values["ID"],
-			strings.Split(values["VERSION_ID"], ".")[0])
-		return getValue(opensuseSeries, codename)
-	default:
-		return genericLinuxSeries, nil
+// readLSBRelease parses the lsb-release file into the os-release values
+// that identify the series.
+func readLSBRelease(f string) (map[string]string, error) {
+	contents, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	return parseLSBRelease(string(contents))
+}
+
+// seriesFromValues returns the series identified by the os-release values.
+// Distributions unknown to this package are derived from their ID_LIKE, or
+// guessed to be generic Linux.
+func (h *Host) seriesFromValues(values map[string]string) (string, Confidence, error) {
+	var (
+		series     string
+		confidence Confidence
+		err        error
+	)
+	if h.Root != "" {
+		series, confidence, err = h.seriesFromOSRelease(values, Exact)
+	} else {
+		series, err = seriesFromReleaseValues(values)
+		confidence = Exact
+	}
+	if !knownOSReleaseID(values["ID"]) {
+		if series == genericLinuxSeries {
+			confidence = Guessed
+		} else if confidence < Derived {
+			confidence = Derived
+		}
 	}
+	return series, confidence, err
 }
 
-func getValue(from map[string]string, val string) (string, error) {
-	for serie, ver := range from {
-		if ver == val {
-			return serie, nil
+// seriesFromOSRelease returns the series of a host with a Root. Ubuntu
+// versions unknown to this package are resolved against the host's own
+// distro-info, without updating the package's series versions, which
+// describe the machine the current process is running on.
+func (h *Host) seriesFromOSRelease(values map[string]string, confidence Confidence) (string, Confidence, error) {
+	seriesVersionsMutex.RLock()
+	series, err := seriesFromOSRelease(values)
+	seriesVersionsMutex.RUnlock()
+	if err == nil || values["ID"] != strings.ToLower(jujuos.Ubuntu.String()) {
+		return series, confidence, err
+	}
+	distroInfo := NewDistroInfo(h.path(UbuntuDistroInfo))
+	if err := distroInfo.Refresh(); err != nil {
+		return "unknown", confidence, errors.Trace(err)
+	}
+	for name, info := range distroInfo.info {
+		if strings.TrimSuffix(info.Version, " LTS") == values["VERSION_ID"] {
+			return name, Derived, nil
 		}
 	}
-	return "unknown", errors.New("could not determine series")
+	return series, confidence, err
 }
 
-func getValueFromSeriesVersion(from map[string]SeriesVersionInfo, val string) (string, error) {
-	for s, version := range from {
-		if version.Version == val {
-			return s, nil
+// kernelReleaseEL matches the Enterprise Linux release that a RHEL family
+// kernel was built for, such as the "el7" of "3.10.0-1160.el7.x86_64".
+var kernelReleaseEL = regexp.MustCompile(`\.el([0-9]+)`)
+
+// seriesFromKernelRelease guesses the series of the host from its kernel
+// release. Kernels built for a supported CentOS release are attributed to
+// it; any other kernel is assumed to be generic Linux.
+func (h *Host) seriesFromKernelRelease() (string, error) {
+	contents, err := ioutil.ReadFile(h.path(kernelReleaseFile))
+	if err != nil {
+		return "unknown", errors.Trace(err)
+	}
+	if match := kernelReleaseEL.FindStringSubmatch(string(contents)); match != nil {
+		if _, ok := centosSeries["centos"+match[1]]; ok {
+			return "centos" + match[1], nil
 		}
 	}
-	return "unknown", errors.New("could not determine series")
+	return genericLinuxSeries, nil
+}
+
+// redhatRelease matches the contents of the redhat-release file, such as
+// "CentOS Linux release 7.9.2009 (Core)".
+var redhatRelease = regexp.MustCompile(`^(.+?) release ([0-9]+)`)
+
+// readRedhatRelease parses the redhat-release file into the os-release
+// values that identify the series. RHEL is treated as CentOS, which is
+// built from the same sources.
+func readRedhatRelease(f string) (map[string]string, error) {
+	contents, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	match := redhatRelease.FindStringSubmatch(strings.TrimSpace(string(contents)))
+	if match == nil {
+		return nil, errors.Errorf("unexpected contents in %s", f)
+	}
+	id := strings.ToLower(strings.Fields(match[1])[0])
+	if strings.HasPrefix(match[1], "Red Hat Enterprise Linux") {
+		id = strings.ToLower(jujuos.CentOS.String())
+	}
+	return map[string]string{
+		"ID":         id,
+		"VERSION_ID": match[2],
+	}, nil
 }
 
 // ReleaseVersion looks for the value of VERSION_ID in the content of
 // the os-release.  If the value is not found, the file is not found, or
 // an error occurs reading the file, an empty string is returned.
 func ReleaseVersion() string {
-	release, err := jujuos.ReadOSRelease(osReleaseFile)
+	release, err := defaultHost.readOSRelease()
 	if err != nil {
 		return ""
 	}
@@ -89,37 +233,11 @@ func updateLocalSeriesVersions() error {
 		return errors.Trace(err)
 	}
 
-	now := timeNow().UTC()
-
-	for seriesName, version := range distroInfo.info {
-		var esm bool
-		if existing, ok := ubuntuSeries[seriesName]; ok {
-			esm = existing.ESMSupported
-		}
-
-		// The numeric version may contain a LTS moniker so strip that out.
-		trimmedVersion := strings.TrimSuffix(version.Version, " LTS")
-		seriesVersions[seriesName] = trimmedVersion
-
-		// If the series already exists inside of ubuntuSeries then don't
-		// overwrite that existing one, except to update the supported status.
-		supported := version.Supported(now)
-
-		if us, ok := ubuntuSeries[seriesName]; ok {
-			us.Supported = us.Supported && supported
-			ubuntuSeries[seriesName] = us
-			continue
-		}
-
-		ubuntuSeries[seriesName] = SeriesVersionInfo{
-			Version:                  version.Version,
-			Supported:                false,
-			ESMSupported:             esm,
-			LTS:                      version.LTS(),
-			CreatedByLocalDistroInfo: true,
-		}
-	}
-
+	distroInfo.mutex.RLock()
+	defer distroInfo.mutex.RUnlock()
+	mergeDistroInfo(distroInfo.info, func(info *SeriesVersionInfo) {
+		info.CreatedByLocalDistroInfo = true
+	})
 	return nil
 }
 
@@ -134,3 +252,13 @@ func (defaultFileSystem) Exists(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
 }
+
+// homeURL returns the HOME_URL from the host's os-release file, or an
+// empty string if it can't be read.
+func (h *Host) homeURL() string {
+	values, err := h.readOSRelease()
+	if err != nil {
+		return ""
+	}
+	return values["HOME_URL"]
+}
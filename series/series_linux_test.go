@@ -4,9 +4,11 @@
 package series_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -35,6 +37,8 @@ var _ = gc.Suite(&linuxVersionSuite{})
 
 func (s *linuxVersionSuite) SetUpTest(c *gc.C) {
 	s.CleanupSuite.SetUpTest(c)
+	s.PatchValue(series.UsrLibOSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+	s.PatchValue(series.LSBReleaseFile, filepath.Join(c.MkDir(), "lsb-release"))
 
 	cleanup := series.SetSeriesVersions(make(map[string]string))
 	s.AddCleanup(func(*gc.C) { cleanup() })
@@ -157,6 +161,8 @@ var _ = gc.Suite(&readSeriesSuite{})
 
 func (s *readSeriesSuite) SetUpTest(c *gc.C) {
 	s.CleanupSuite.SetUpTest(c)
+	s.PatchValue(series.UsrLibOSReleaseFile, filepath.Join(c.MkDir(), "os-release"))
+	s.PatchValue(series.LSBReleaseFile, filepath.Join(c.MkDir(), "lsb-release"))
 
 	cleanup := series.SetSeriesVersions(make(map[string]string))
 	s.AddCleanup(func(*gc.C) { cleanup() })
@@ -182,6 +188,16 @@ ID=ubuntu
 VERSION_ID= "12.04" `,
 	"precise",
 	"",
+}, {
+	`# Generated by the image builder
+NAME="Ubuntu"
+
+	ID=ubuntu	# comment
+VERSION_ID =	"12.04"	# comment
+not a value
+`,
+	"precise",
+	"",
 }, {
 	`NAME='Ubuntu'
 ID='ubuntu'
@@ -203,6 +219,140 @@ VERSION_ID="42.2"
 `,
 	"opensuseleap",
 	"",
+}, {
+	`NAME="Amazon Linux"
+VERSION="2"
+ID="amzn"
+ID_LIKE="centos rhel fedora"
+VERSION_ID="2"
+PRETTY_NAME="Amazon Linux 2"
+ANSI_COLOR="0;33"
+CPE_NAME="cpe:2.3:o:amazon:amazon_linux:2"
+HOME_URL="https://amazonlinux.com/"
+`,
+	"amzn2",
+	"",
+}, {
+	`NAME="Amazon Linux"
+VERSION="2023"
+ID="amzn"
+ID_LIKE="fedora"
+VERSION_ID="2023"
+PLATFORM_ID="platform:al2023"
+PRETTY_NAME="Amazon Linux 2023.5.20240708"
+ANSI_COLOR="0;33"
+CPE_NAME="cpe:2.3:o:amazon:amazon_linux:2023"
+HOME_URL="https://aws.amazon.com/linux/amazon-linux-2023/"
+`,
+	"amzn2023",
+	"",
+}, {
+	`NAME="Oracle Linux Server"
+VERSION="8.9"
+ID="ol"
+ID_LIKE="fedora"
+VARIANT="Server"
+VARIANT_ID="server"
+VERSION_ID="8.9"
+PLATFORM_ID="platform:el8"
+PRETTY_NAME="Oracle Linux Server 8.9"
+ANSI_COLOR="0;31"
+CPE_NAME="cpe:/o:oracle:linux:8:9:server"
+HOME_URL="https://linux.oracle.com/"
+`,
+	"oracle8",
+	"",
+}, {
+	`NAME="Oracle Linux Server"
+VERSION="9.3"
+ID="ol"
+ID_LIKE="fedora"
+VARIANT="Server"
+VARIANT_ID="server"
+VERSION_ID="9.3"
+PLATFORM_ID="platform:el9"
+PRETTY_NAME="Oracle Linux Server 9.3"
+ANSI_COLOR="0;31"
+CPE_NAME="cpe:/o:oracle:linux:9:3:server"
+HOME_URL="https://linux.oracle.com/"
+BUG_REPORT_URL="https://github.com/oracle/oracle-linux"
+
+ORACLE_BUGZILLA_PRODUCT="Oracle Linux 9"
+ORACLE_BUGZILLA_PRODUCT_VERSION=9.3
+ORACLE_SUPPORT_PRODUCT="Oracle Linux"
+ORACLE_SUPPORT_PRODUCT_VERSION=9.3
+`,
+	"oracle9",
+	"",
+}, {
+	`NAME="Fedora Remix"
+ID="remix"
+ID_LIKE="fedora"
+VERSION_ID="9.3"
+PLATFORM_ID="platform:el9"
+`,
+	"centos9",
+	"",
+}, {
+	`NAME="Fedora Linux"
+VERSION="40 (Server Edition)"
+ID=fedora
+VERSION_ID=40
+PLATFORM_ID="platform:f40"
+`,
+	"genericlinux",
+	"",
+}, {
+	`NAME="SLES"
+VERSION="12-SP5"
+VERSION_ID="12.5"
+PRETTY_NAME="SUSE Linux Enterprise Server 12 SP5"
+ID="sles"
+ANSI_COLOR="0;32"
+CPE_NAME="cpe:/o:suse:sles:12:sp5"
+`,
+	"sles12",
+	"",
+}, {
+	`NAME="openSUSE Tumbleweed"
+# VERSION="20240115"
+ID="opensuse-tumbleweed"
+ID_LIKE="opensuse suse"
+VERSION_ID="20240115"
+PRETTY_NAME="openSUSE Tumbleweed"
+ANSI_COLOR="0;32"
+CPE_NAME="cpe:/o:opensuse:tumbleweed:20240115"
+BUG_REPORT_URL="https://bugzilla.opensuse.org"
+HOME_URL="https://www.opensuse.org/"
+DOCUMENTATION_URL="https://en.opensuse.org/Portal:Tumbleweed"
+LOGO="distributor-logo-Tumbleweed"
+`,
+	"opensusetumbleweed",
+	"",
+}, {
+	`NAME="SLES"
+VERSION="15-SP4"
+VERSION_ID="15.4"
+PRETTY_NAME="SUSE Linux Enterprise Server 15 SP4"
+ID="sles"
+ID_LIKE="suse"
+ANSI_COLOR="0;32"
+CPE_NAME="cpe:/o:suse:sles:15:sp4"
+`,
+	"sles15",
+	"",
+}, {
+	`NAME="SLES"
+VERSION="15-SP5"
+VERSION_ID="15.5"
+PRETTY_NAME="SUSE Linux Enterprise Server 15 SP5"
+ID="sles"
+ID_LIKE="suse"
+ANSI_COLOR="0;32"
+CPE_NAME="cpe:/o:suse:sles:15:sp5"
+`,
+	"sles15",
+	"",
 }, {
 	`NAME="Ubuntu"
 VERSION="14.04.1 LTS, Trusty Tahr"
@@ -263,6 +413,187 @@ ID=opensuse
 VERSION_ID="42.3"`,
 	"opensuseleap",
 	"",
+}, {
+	`NAME="Ubuntu"
+VERSION="22.04.3 LTS (Jammy Jellyfish)"
+ID=ubuntu
+ID_LIKE=debian
+VERSION_ID="22.04"
+VERSION_CODENAME=jammy
+UBUNTU_CODENAME=jammy
+`,
+	"jammy",
+	"",
+}, {
+	`NAME="Ubuntu"
+ID=ubuntu
+VERSION_ID="99.10"
+VERSION_CODENAME=spock
+`,
+	"spock",
+	"",
+}, {
+	`NAME="Ubuntu"
+ID=ubuntu
+VERSION_ID="99.10"
+UBUNTU_CODENAME=kirk
+`,
+	"kirk",
+	"",
+}, {
+	`PRETTY_NAME="Debian GNU/Linux 10 (buster)"
+NAME="Debian GNU/Linux"
+VERSION_ID="10"
+VERSION="10 (buster)"
+VERSION_CODENAME=buster
+ID=debian
+HOME_URL="https://www.debian.org/"
+SUPPORT_URL="https://www.debian.org/support"
+BUG_REPORT_URL="https://bugs.debian.org/"
+`,
+	"debian10",
+	"",
+}, {
+	`PRETTY_NAME="Debian GNU/Linux 11 (bullseye)"
+NAME="Debian GNU/Linux"
+VERSION_ID="11"
+VERSION="11 (bullseye)"
+VERSION_CODENAME=bullseye
+ID=debian
+HOME_URL="https://www.debian.org/"
+SUPPORT_URL="https://www.debian.org/support"
+BUG_REPORT_URL="https://bugs.debian.org/"
+`,
+	"debian11",
+	"",
+}, {
+	`PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+NAME="Debian GNU/Linux"
+VERSION_ID="12"
+VERSION="12 (bookworm)"
+VERSION_CODENAME=bookworm
+ID=debian
+HOME_URL="https://www.debian.org/"
+SUPPORT_URL="https://www.debian.org/support"
+BUG_REPORT_URL="https://bugs.debian.org/"
+`,
+	"debian12",
+	"",
+}, {
+	`PRETTY_NAME="Debian GNU/Linux trixie/sid"
+NAME="Debian GNU/Linux"
+VERSION_CODENAME=trixie
+ID=debian
+`,
+	"unknown",
+	"could not determine series",
+}, {
+	`NAME="Alpine Linux"
+ID=alpine
+VERSION_ID=3.18.4
+PRETTY_NAME="Alpine Linux v3.18"
+HOME_URL="https://alpinelinux.org/"
+BUG_REPORT_URL="https://gitlab.alpinelinux.org/alpine/aports/-/issues"
+`,
+	"alpine3.18",
+	"",
+}, {
+	`NAME="Alpine Linux"
+ID=alpine
+VERSION_ID=3.20.0_alpha20240329
+PRETTY_NAME="Alpine Linux edge"
+`,
+	"alpine3.20",
+	"",
+}, {
+	`NAME="Rocky Linux"
+VERSION="9.2 (Blue Onyx)"
+ID="rocky"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="9.2"
+PLATFORM_ID="platform:el9"
+PRETTY_NAME="Rocky Linux 9.2 (Blue Onyx)"
+HOME_URL="https://rockylinux.org/"
+`,
+	"centos9",
+	"",
+}, {
+	`NAME="Rocky Linux"
+VERSION="8.9 (Green Obsidian)"
+ID="rocky"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="8.9"
+PRETTY_NAME="Rocky Linux 8.9 (Green Obsidian)"
+`,
+	"centos8",
+	"",
+}, {
+	`NAME="AlmaLinux"
+VERSION="9.4 (Seafoam Ocelot)"
+ID="almalinux"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="9.4"
+PLATFORM_ID="platform:el9"
+PRETTY_NAME="AlmaLinux 9.4 (Seafoam Ocelot)"
+HOME_URL="https://almalinux.org/"
+`,
+	"centos9",
+	"",
+}, {
+	`NAME="AlmaLinux"
+ID="almalinux"
+VERSION_ID="6.10"
+`,
+	"unknown",
+	"could not determine series",
+}, {
+	`PRETTY_NAME="Raspbian GNU/Linux 12 (bookworm)"
+NAME="Raspbian GNU/Linux"
+VERSION_ID="12"
+VERSION="12 (bookworm)"
+VERSION_CODENAME=bookworm
+ID=raspbian
+ID_LIKE=debian
+`,
+	"debian12",
+	"",
+}, {
+	`NAME="Linux Mint"
+VERSION="21.2 (Victoria)"
+ID=linuxmint
+ID_LIKE="ubuntu debian"
+PRETTY_NAME="Linux Mint 21.2"
+VERSION_ID="21.2"
+VERSION_CODENAME=victoria
+UBUNTU_CODENAME=jammy
+`,
+	"jammy",
+	"",
+}, {
+	`NAME="Example Linux"
+ID=example
+ID_LIKE="ubuntu debian"
+VERSION_ID="11"
+VERSION_CODENAME=example
+`,
+	"debian11",
+	"",
+}, {
+	`NAME="Gentoo"
+ID=gentoo
+ID_LIKE=""
+VERSION_ID="2.14"
+`,
+	"genericlinux",
+	"",
+}, {
+	`NAME="Kali GNU/Linux"
+ID=kali
+ID_LIKE=debian
+VERSION_ID="2024.1"
+`,
+	"genericlinux",
+	"",
 },
 }
 
@@ -284,3 +615,205 @@ func (s *readSeriesSuite) TestReadSeries(c *gc.C) {
 		c.Assert(series, gc.Equals, t.series)
 	}
 }
+
+func (s *readSeriesSuite) TestReadSeriesWithByteOrderMark(c *gc.C) {
+	f := filepath.Join(c.MkDir(), "os-release")
+	s.PatchValue(series.OSReleaseFile, f)
+	// The byte order mark would otherwise be taken as part of the ID key.
+	contents := append([]byte{0xef, 0xbb, 0xbf}, `ID=ubuntu
+NAME="Ubuntu"
+VERSION="22.04.4 LTS (Jammy Jellyfish)"
+ID_LIKE=debian
+VERSION_ID="22.04"
+`...)
+	err := ioutil.WriteFile(f, contents, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hostSeries, err := series.ReadSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostSeries, gc.Equals, "jammy")
+}
+
+func (s *readSeriesSuite) TestReadSeriesFromRedhatRelease(c *gc.C) {
+	d := c.MkDir()
+	s.PatchValue(series.OSReleaseFile, filepath.Join(d, "os-release"))
+	f := filepath.Join(d, "redhat-release")
+	s.PatchValue(series.RedhatReleaseFile, f)
+
+	for i, t := range []struct {
+		contents string
+		series   string
+		err      string
+	}{{
+		contents: "CentOS Linux release 7.9.2009 (Core)\n",
+		series:   "centos7",
+	}, {
+		contents: "Red Hat Enterprise Linux release 8.4 (Ootpa)\n",
+		series:   "centos8",
+	}, {
+		contents: "Rocky Linux release 9.2 (Blue Onyx)\n",
+		series:   "centos9",
+	}, {
+		contents: "AlmaLinux release 8.9 (Midnight Oncilla)\n",
+		series:   "centos8",
+	}, {
+		contents: "Fedora release 24 (Twenty Four)\n",
+		series:   "genericlinux",
+	}, {
+		contents: "garbage\n",
+		series:   "unknown",
+		err:      "unexpected contents in .*redhat-release",
+	}} {
+		c.Logf("test %d", i)
+		err := ioutil.WriteFile(f, []byte(t.contents), 0666)
+		c.Assert(err, jc.ErrorIsNil)
+		series, err := series.ReadSeries()
+		if t.err == "" {
+			c.Assert(err, jc.ErrorIsNil)
+		} else {
+			c.Assert(err, gc.ErrorMatches, t.err)
+		}
+		c.Assert(series, gc.Equals, t.series)
+	}
+}
+
+func (s *linuxVersionSuite) TestHostSeriesCached(c *gc.C) {
+	d := c.MkDir()
+	release := filepath.Join(d, "os-release")
+	s.PatchValue(series.OSReleaseFile, release)
+	s.PatchValue(series.RedhatReleaseFile, filepath.Join(d, "redhat-release"))
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(d, "ubuntu.csv"))
+	series.ResetHostSeriesCache()
+	s.AddCleanup(func(*gc.C) { series.ResetHostSeriesCache() })
+
+	// A failure is cached just like a success.
+	_, err := series.HostSeries()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: .*")
+	err = ioutil.WriteFile(release, []byte("ID=ubuntu\nVERSION_ID=\"22.04\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = series.HostSeries()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: .*")
+
+	series.ResetHostSeriesCache()
+	hostSeries, err := series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostSeries, gc.Equals, "jammy")
+
+	err = ioutil.WriteFile(release, []byte("ID=ubuntu\nVERSION_ID=\"24.04\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	hostSeries, err = series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostSeries, gc.Equals, "jammy")
+
+	series.ResetHostSeriesCache()
+	hostSeries, err = series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostSeries, gc.Equals, "noble")
+}
+
+func (s *linuxVersionSuite) TestHostReleaseVersionGenericLinux(c *gc.C) {
+	d := c.MkDir()
+	release := filepath.Join(d, "os-release")
+	s.PatchValue(series.OSReleaseFile, release)
+	s.PatchValue(series.RedhatReleaseFile, filepath.Join(d, "redhat-release"))
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(d, "ubuntu.csv"))
+	series.ResetHostSeriesCache()
+	s.AddCleanup(func(*gc.C) { series.ResetHostSeriesCache() })
+	err := ioutil.WriteFile(release, []byte("NAME=Gentoo\nID=gentoo\nVERSION_ID=\"2.14\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hostSeries, err := series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "genericlinux")
+	version, err := series.HostReleaseVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "2.14")
+
+	// The version is cached along with the series.
+	err = ioutil.WriteFile(release, []byte("NAME=Gentoo\nID=gentoo\nVERSION_ID=\"2.15\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	version, err = series.HostReleaseVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "2.14")
+
+	series.ResetHostSeriesCache()
+	version, err = series.HostReleaseVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "2.15")
+}
+
+func (s *linuxVersionSuite) TestHostReleaseVersionMissing(c *gc.C) {
+	d := c.MkDir()
+	release := filepath.Join(d, "os-release")
+	s.PatchValue(series.OSReleaseFile, release)
+	s.PatchValue(series.RedhatReleaseFile, filepath.Join(d, "redhat-release"))
+	series.ResetHostSeriesCache()
+	s.AddCleanup(func(*gc.C) { series.ResetHostSeriesCache() })
+	err := ioutil.WriteFile(release, []byte("NAME=\"Arch Linux\"\nID=arch\nBUILD_ID=rolling\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = series.HostReleaseVersion()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host release version: VERSION_ID not found")
+	c.Check(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *readSeriesSuite) TestReadSeriesFromUsrLibOSRelease(c *gc.C) {
+	d := c.MkDir()
+	s.PatchValue(series.OSReleaseFile, filepath.Join(d, "os-release"))
+	s.PatchValue(series.RedhatReleaseFile, filepath.Join(d, "redhat-release"))
+	f := filepath.Join(d, "usr-lib-os-release")
+	s.PatchValue(series.UsrLibOSReleaseFile, f)
+	err := ioutil.WriteFile(f, []byte("NAME=\"Ubuntu\"\nID=ubuntu\nVERSION_ID=\"22.04\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hostSeries, err := series.ReadSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostSeries, gc.Equals, "jammy")
+}
+
+func (s *readSeriesSuite) TestReadSeriesFromLSBRelease(c *gc.C) {
+	d := c.MkDir()
+	f := filepath.Join(d, "lsb-release")
+	s.PatchValue(series.LSBReleaseFile, f)
+	s.PatchValue(series.RedhatReleaseFile, filepath.Join(d, "redhat-release"))
+
+	for i, t := range []struct {
+		osRelease string
+		contents  string
+		series    string
+		err       string
+	}{{
+		contents: "DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=14.04\nDISTRIB_CODENAME=trusty\nDISTRIB_DESCRIPTION=\"Ubuntu 14.04.6 LTS\"\n",
+		series:   "trusty",
+	}, {
+		contents: "DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=16.04\n",
+		series:   "xenial",
+	}, {
+		osRelease: "NAME=\"Ubuntu\"\n",
+		contents:  "DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=18.04\nDISTRIB_CODENAME=bionic\n",
+		series:    "bionic",
+	}, {
+		osRelease: "NAME=\"Ubuntu\"\n",
+		contents:  "DISTRIB_RELEASE=18.04\n",
+		series:    "unknown",
+		err:       "OS release file is missing ID",
+	}} {
+		c.Logf("test %d", i)
+		osRelease := filepath.Join(d, fmt.Sprintf("os-release-%d", i))
+		if t.osRelease != "" {
+			err := ioutil.WriteFile(osRelease, []byte(t.osRelease), 0644)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		s.PatchValue(series.OSReleaseFile, osRelease)
+		err := ioutil.WriteFile(f, []byte(t.contents), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+
+		hostSeries, err := series.ReadSeries()
+		if t.err == "" {
+			c.Assert(err, jc.ErrorIsNil)
+		} else {
+			c.Assert(err, gc.ErrorMatches, t.err)
+		}
+		c.Check(hostSeries, gc.Equals, t.series)
+	}
+}
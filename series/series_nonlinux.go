@@ -37,3 +37,15 @@ func (defaultFileSystem) Open(path string) (*os.File, error) {
 func (defaultFileSystem) Exists(path string) bool {
 	return false
 }
+
+// homeURL has no meaning except on Linux.
+func (h *Host) homeURL() string {
+	return ""
+}
+
+// DetectSeries returns the series of the host. Outside of Linux the series
+// is always read from the OS itself, so the confidence is Exact.
+func (h *Host) DetectSeries() (string, Confidence, error) {
+	series, err := h.Series()
+	return series, Exact, err
+}
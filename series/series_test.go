@@ -4,8 +4,14 @@
 package series_test
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -64,6 +70,67 @@ func (*kernelVersionSuite) TestKernelToMajorVersionEmpty(c *gc.C) {
 	c.Check(majorVersion, gc.Equals, 0)
 }
 
+func (*kernelVersionSuite) TestKernelToMajorMinor(c *gc.C) {
+	for i, test := range []struct {
+		version      string
+		major, minor int
+		err          string
+	}{
+		{version: "5.15.0-91-generic", major: 5, minor: 15},
+		{version: "4.19-rc1", major: 4, minor: 19},
+		{version: "23.4.0", major: 23, minor: 4},
+		{version: "1234", major: 1234},
+		{version: "5.x", err: `strconv.ParseInt: parsing "": invalid syntax`},
+		{version: "a.b.c", err: `strconv.ParseInt: parsing "a": invalid syntax`},
+	} {
+		c.Logf("%d: %s", i, test.version)
+		version := test.version
+		major, minor, err := series.KernelToMajorMinor(func() (string, error) {
+			return version, nil
+		})
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(major, gc.Equals, test.major)
+		c.Check(minor, gc.Equals, test.minor)
+	}
+}
+
+func (*kernelVersionSuite) TestParseKernelRelease(c *gc.C) {
+	for i, test := range []struct {
+		release      string
+		major, minor int
+		err          string
+	}{
+		// Ubuntu
+		{release: "5.15.0-91-generic", major: 5, minor: 15},
+		{release: "6.8.0-31-lowlatency", major: 6, minor: 8},
+		// CentOS
+		{release: "3.10.0-1160.el7.x86_64", major: 3, minor: 10},
+		{release: "5.14.0-362.8.1.el9_3.x86_64", major: 5, minor: 14},
+		// macOS
+		{release: "23.4.0", major: 23, minor: 4},
+		{release: "19.6.0", major: 19, minor: 6},
+		// Mainline release candidates
+		{release: "5.4-rc1", major: 5, minor: 4},
+		{release: "6", err: `kernel release "6" not valid`},
+		{release: "", err: `kernel release "" not valid`},
+		{release: "linux-5.15", err: `kernel release "linux-5.15" not valid`},
+	} {
+		c.Logf("%d: %q", i, test.release)
+		major, minor, err := series.ParseKernelRelease(test.release)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(major, gc.Equals, test.major)
+		c.Check(minor, gc.Equals, test.minor)
+	}
+}
+
 func (*kernelVersionSuite) TestMacOSXSeriesFromKernelVersion(c *gc.C) {
 	series, err := series.MacOSXSeriesFromKernelVersion(sysctlMacOS10dot9dot2)
 	c.Assert(err, jc.ErrorIsNil)
@@ -91,6 +158,9 @@ func (*kernelVersionSuite) TestMacOSXSeries(c *gc.C) {
 		{version: 15, series: "elcapitan"},
 		{version: 16, series: "sierra"},
 		{version: 18, series: "mojave"},
+		{version: 23, series: "sonoma"},
+		{version: 24, series: "sequoia"},
+		{version: 25, series: "unknown", err: `unknown series version 25`},
 		{version: 4, series: "unknown", err: `unknown series version 4`},
 		{version: 0, series: "unknown", err: `unknown series version 0`},
 	}
@@ -105,6 +175,84 @@ func (*kernelVersionSuite) TestMacOSXSeries(c *gc.C) {
 	}
 }
 
+func (*kernelVersionSuite) TestMacOSSeriesFromProductVersion(c *gc.C) {
+	for i, test := range []struct {
+		version string
+		series  string
+		err     string
+	}{
+		{version: "10.9.2", series: "mavericks"},
+		{version: "10.14", series: "mojave"},
+		{version: "10.15", series: "catalina"},
+		{version: "10.15.7", series: "catalina"},
+		{version: "10.16", series: "bigsur"},
+		{version: "11", series: "bigsur"},
+		{version: "11.7.10", series: "bigsur"},
+		{version: "12", series: "monterey"},
+		{version: "13.6", series: "ventura"},
+		{version: "14.5", series: "sonoma"},
+		{version: "15", series: "sequoia"},
+		{version: "15.0", series: "sequoia"},
+		{version: "16", err: `macOS series for version "16" not found`},
+		{version: "10.0", err: `macOS series for version "10.0" not found`},
+		{version: "9.2", err: `macOS series for version "9.2" not found`},
+		{version: "10", err: `macOS version "10" not valid`},
+		{version: "10.x", err: `macOS version "10.x" not valid`},
+		{version: "", err: `macOS version "" not valid`},
+	} {
+		c.Logf("%d: %q", i, test.version)
+		osxSeries, err := series.MacOSSeriesFromProductVersion(test.version)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(osxSeries, gc.Equals, "unknown")
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(osxSeries, gc.Equals, test.series)
+	}
+}
+
+type fakeRunner struct {
+	calls  [][]string
+	output map[string]string
+	err    error
+}
+
+func (r *fakeRunner) Run(name string, args ...string) (string, error) {
+	cmd := append([]string{name}, args...)
+	r.calls = append(r.calls, cmd)
+	if r.err != nil {
+		return "", r.err
+	}
+	out, ok := r.output[strings.Join(cmd, " ")]
+	if !ok {
+		return "", fmt.Errorf("running %s: exit status 1", name)
+	}
+	return out, nil
+}
+
+func (s *kernelVersionSuite) TestMacOSXSeriesFromUname(c *gc.C) {
+	runner := &fakeRunner{output: map[string]string{
+		"uname -r": "23.4.0\n",
+	}}
+	restore := series.SetCommandRunner(runner)
+	defer restore()
+
+	osxSeries, err := series.MacOSXSeriesFromUname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osxSeries, gc.Equals, "sonoma")
+	c.Check(runner.calls, jc.DeepEquals, [][]string{{"uname", "-r"}})
+}
+
+func (s *kernelVersionSuite) TestMacOSXSeriesFromUnameError(c *gc.C) {
+	restore := series.SetCommandRunner(&fakeRunner{err: fmt.Errorf("uname not found")})
+	defer restore()
+
+	osxSeries, err := series.MacOSXSeriesFromUname()
+	c.Assert(err, gc.ErrorMatches, "uname not found")
+	c.Check(osxSeries, gc.Equals, "unknown")
+}
+
 type seriesSuite struct {
 	testing.CleanupSuite
 }
@@ -120,3 +268,116 @@ func (s *seriesSuite) TestHostSeriesOverride(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ser, gc.Equals, "freelunch")
 }
+
+func (s *seriesSuite) TestHostSeriesContext(c *gc.C) {
+	s.PatchValue(series.ReadHostSeries, func() (string, error) {
+		return "jammy", nil
+	})
+	series.ResetHostSeriesCache()
+	s.AddCleanup(func(*gc.C) { series.ResetHostSeriesCache() })
+
+	hostSeries, err := series.HostSeriesContext(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "jammy")
+}
+
+func (s *seriesSuite) TestHostSeriesContextCancelled(c *gc.C) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	s.PatchValue(series.ReadHostSeries, func() (string, error) {
+		close(started)
+		<-unblock
+		return "jammy", nil
+	})
+	series.ResetHostSeriesCache()
+	s.AddCleanup(func(*gc.C) { series.ResetHostSeriesCache() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := series.HostSeriesContext(ctx)
+		errs <- err
+	}()
+	select {
+	case <-started:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("detection not started")
+	}
+	cancel()
+	select {
+	case err := <-errs:
+		c.Check(err, gc.Equals, context.Canceled)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("HostSeriesContext did not return after cancellation")
+	}
+
+	// The abandoned detection completes in the background and is cached.
+	close(unblock)
+	hostSeries, err := series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "jammy")
+
+	// A context that is already done doesn't wait for detection at all.
+	_, err = series.HostSeriesContext(ctx)
+	c.Check(err, gc.Equals, context.Canceled)
+}
+
+func (s *seriesSuite) TestHostOS(c *gc.C) {
+	for i, test := range []struct {
+		series string
+		osType os.OSType
+	}{
+		{"jammy", os.Ubuntu},
+		{"centos9", os.CentOS},
+		{"sonoma", os.OSX},
+		{"win2019", os.Windows},
+		{"genericlinux", os.GenericLinux},
+	} {
+		c.Logf("%d: %s", i, test.series)
+		s.PatchValue(&series.HostSeries, func() (string, error) {
+			return test.series, nil
+		})
+		osType, err := series.HostOS()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(osType, gc.Equals, test.osType)
+	}
+}
+
+func (s *seriesSuite) TestHostOSError(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+	s.PatchValue(&series.HostSeries, func() (string, error) {
+		return "unknown", errors.New("cannot determine host series: boom")
+	})
+	osType, err := series.HostOS()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: boom")
+	c.Check(osType, gc.Equals, os.Unknown)
+
+	s.PatchValue(&series.HostSeries, func() (string, error) {
+		return "freelunch", nil
+	})
+	osType, err = series.HostOS()
+	c.Assert(err, gc.ErrorMatches, `unknown OS for series: "freelunch"`)
+	c.Check(osType, gc.Equals, os.Unknown)
+}
+
+func (s *seriesSuite) TestSeriesVersionWithoutDistroInfo(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+	for _, test := range []struct {
+		series  string
+		version string
+	}{
+		{"kinetic", "22.10"},
+		{"lunar", "23.04"},
+		{"mantic", "23.10"},
+		{"noble", "24.04"},
+		{"oracular", "24.10"},
+	} {
+		version, err := series.SeriesVersion(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(version, gc.Equals, test.version)
+
+		version, err = series.UbuntuSeriesVersion(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(version, gc.Equals, test.version)
+	}
+}
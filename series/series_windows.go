@@ -5,13 +5,19 @@
 package series
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/juju/errors"
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 )
 
+// verNTWorkstation is the ProductType of client releases of Windows, as
+// opposed to servers and domain controllers.
+const verNTWorkstation = 1
+
 var (
 	// currentVersionKey is defined as a variable instead of a constant
 	// to allow overwriting during testing
@@ -20,6 +26,10 @@ var (
 	// isNanoKey determines the registry key that can be queried to determine whether
 	// a machine is a nano machine
 	isNanoKey = "Software\\Microsoft\\Windows NT\\CurrentVersion\\Server\\ServerLevels"
+
+	// rtlGetVersion returns the version of the running Windows, regardless
+	// of the compatibility mode of the process (overrideable for testing).
+	rtlGetVersion = windows.RtlGetVersion
 )
 
 func getVersionFromRegistry() (string, error) {
@@ -36,7 +46,40 @@ func getVersionFromRegistry() (string, error) {
 	return s, nil
 }
 
+// ReleaseVersion returns the version of Windows running on the host, such as
+// "10.0.22631".
+func (h *Host) ReleaseVersion() (string, error) {
+	info := rtlGetVersion()
+	return fmt.Sprintf("%d.%d.%d", info.MajorVersion, info.MinorVersion, info.BuildNumber), nil
+}
+
+func (h *Host) readSeries() (string, error) {
+	return readSeries()
+}
+
 func readSeries() (string, error) {
+	info := rtlGetVersion()
+	server := info.ProductType != verNTWorkstation
+	result, err := classifyWindowsBuild(int(info.BuildNumber), server)
+	if err != nil {
+		logger.Debugf("unable to determine series from build %d: %v", info.BuildNumber, err)
+		return readSeriesFromProductName()
+	}
+	// Editions such as Hyper-V Server and Nano Server share their build
+	// with the Windows Server release, and are only distinguished by the
+	// product name. Client releases are identified by their build alone, as
+	// Windows 11 gives its product name as Windows 10.
+	if server {
+		if series, err := readSeriesFromProductName(); err == nil {
+			return series, nil
+		}
+	}
+	return result.Series, nil
+}
+
+// readSeriesFromProductName returns the series of the product name in the
+// registry, such as "Windows Server 2019 Datacenter".
+func readSeriesFromProductName() (string, error) {
 	ver, err := getVersionFromRegistry()
 	if err != nil {
 		return "unknown", errors.Trace(err)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 	gc "gopkg.in/check.v1"
 
@@ -87,6 +88,79 @@ var versionTests = []struct {
 func (s *windowsSeriesSuite) SetUpTest(c *gc.C) {
 	s.CleanupSuite.SetUpTest(c)
 	s.createRegKey(c, series.CurrentVersionKey)
+	// An unknown build leaves the series to the product name.
+	s.patchVersion(0, false)
+}
+
+func (s *windowsSeriesSuite) patchVersion(build uint32, workstation bool) {
+	info := &windows.OsVersionInfoEx{
+		MajorVersion: 10,
+		BuildNumber:  build,
+		ProductType:  3,
+	}
+	if workstation {
+		info.ProductType = 1
+	}
+	s.PatchValue(series.RtlGetVersion, func() *windows.OsVersionInfoEx {
+		return info
+	})
+}
+
+func (s *windowsSeriesSuite) setProductName(c *gc.C, name string) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, *series.CurrentVersionKey, registry.ALL_ACCESS)
+	c.Assert(err, jc.ErrorIsNil)
+	defer k.Close()
+
+	err = k.SetStringValue("ProductName", name)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *windowsSeriesSuite) TestReadSeriesFromBuild(c *gc.C) {
+	for i, test := range []struct {
+		build       uint32
+		workstation bool
+		want        string
+	}{
+		{build: 17763, want: "win2019"},
+		{build: 20348, want: "win2022"},
+		{build: 9600, workstation: true, want: "win81"},
+		{build: 19045, workstation: true, want: "win10"},
+		{build: 22000, workstation: true, want: "win11"},
+		{build: 22631, workstation: true, want: "win11"},
+	} {
+		c.Logf("%d: build %d", i, test.build)
+		s.patchVersion(test.build, test.workstation)
+
+		ver, err := series.ReadSeries()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(ver, gc.Equals, test.want)
+	}
+}
+
+func (s *windowsSeriesSuite) TestReleaseVersion(c *gc.C) {
+	s.patchVersion(22631, true)
+
+	version, err := series.NewHost().ReleaseVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "10.0.22631")
+}
+
+func (s *windowsSeriesSuite) TestReadSeriesClientIgnoresProductName(c *gc.C) {
+	s.setProductName(c, "Windows 10 Pro")
+	s.patchVersion(22631, true)
+
+	ver, err := series.ReadSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, "win11")
+}
+
+func (s *windowsSeriesSuite) TestReadSeriesServerEditionFromProductName(c *gc.C) {
+	s.setProductName(c, "Hyper-V Server 2016")
+	s.patchVersion(14393, false)
+
+	ver, err := series.ReadSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, "win2016hv")
 }
 
 func (s *windowsSeriesSuite) createRegKey(c *gc.C, key *string) {
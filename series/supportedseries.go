@@ -4,11 +4,14 @@
 package series
 
 import (
+	"io"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -62,49 +65,66 @@ func IsUnknownVersionSeriesError(err error) bool {
 // On non-Ubuntu systems, these values provide a nice fallback option.
 // Exported so tests can change the values to ensure the distro-info lookup works.
 var seriesVersions = map[string]string{
-	"precise":          "12.04",
-	"quantal":          "12.10",
-	"raring":           "13.04",
-	"saucy":            "13.10",
-	"trusty":           "14.04",
-	"utopic":           "14.10",
-	"vivid":            "15.04",
-	"wily":             "15.10",
-	"xenial":           "16.04",
-	"yakkety":          "16.10",
-	"zesty":            "17.04",
-	"artful":           "17.10",
-	"bionic":           "18.04",
-	"cosmic":           "18.10",
-	"disco":            "19.04",
-	"eoan":             "19.10",
-	"focal":            "20.04",
-	"groovy":           "20.10",
-	"hirsute":          "21.04",
-	"impish":           "21.10",
-	"jammy":            "22.04",
-	"kinetic":          "22.10",
-	"lunar":            "23.04",
-	"mantic":           "23.10",
-	"noble":            "24.04",
-	"win2008r2":        "win2008r2",
-	"win2012hvr2":      "win2012hvr2",
-	"win2012hv":        "win2012hv",
-	"win2012r2":        "win2012r2",
-	"win2012":          "win2012",
-	"win2016":          "win2016",
-	"win2016hv":        "win2016hv",
-	"win2016nano":      "win2016nano",
-	"win2019":          "win2019",
-	"win7":             "win7",
-	"win8":             "win8",
-	"win81":            "win81",
-	"win10":            "win10",
-	"centos7":          "centos7",
-	"centos8":          "centos8",
-	"centos9":          "centos9",
-	"opensuseleap":     "opensuse42",
-	genericLinuxSeries: genericLinuxVersion,
+	"precise":            "12.04",
+	"quantal":            "12.10",
+	"raring":             "13.04",
+	"saucy":              "13.10",
+	"trusty":             "14.04",
+	"utopic":             "14.10",
+	"vivid":              "15.04",
+	"wily":               "15.10",
+	"xenial":             "16.04",
+	"yakkety":            "16.10",
+	"zesty":              "17.04",
+	"artful":             "17.10",
+	"bionic":             "18.04",
+	"cosmic":             "18.10",
+	"disco":              "19.04",
+	"eoan":               "19.10",
+	"focal":              "20.04",
+	"groovy":             "20.10",
+	"hirsute":            "21.04",
+	"impish":             "21.10",
+	"jammy":              "22.04",
+	"kinetic":            "22.10",
+	"lunar":              "23.04",
+	"mantic":             "23.10",
+	"noble":              "24.04",
+	"oracular":           "24.10",
+	"win2008r2":          "win2008r2",
+	"win2012hvr2":        "win2012hvr2",
+	"win2012hv":          "win2012hv",
+	"win2012r2":          "win2012r2",
+	"win2012":            "win2012",
+	"win2016":            "win2016",
+	"win2016hv":          "win2016hv",
+	"win2016nano":        "win2016nano",
+	"win2019":            "win2019",
+	"win2022":            "win2022",
+	"win7":               "win7",
+	"win8":               "win8",
+	"win81":              "win81",
+	"win10":              "win10",
+	"win11":              "win11",
+	"centos7":            "centos7",
+	"centos8":            "centos8",
+	"centos9":            "centos9",
+	"amzn2":              "amzn2",
+	"amzn2023":           "amzn2023",
+	"oracle8":            "oracle8",
+	"oracle9":            "oracle9",
+	"opensuseleap":       "opensuse42",
+	"opensusetumbleweed": "opensusetumbleweed",
+	"sles12":             "sles12",
+	"sles15":             "sles15",
+	"debian10":           "debian10",
+	"debian11":           "debian11",
+	"debian12":           "debian12",
+	"alpine3.17":         "alpine3.17",
+	"alpine3.18":         "alpine3.18",
+	"alpine3.19":         "alpine3.19",
+	"alpine3.20":         "alpine3.20",
+	genericLinuxSeries:   genericLinuxVersion,
 }
 
 // versionSeries provides a mapping between versions and series names.
@@ -116,8 +136,52 @@ var centosSeries = map[string]string{
 	"centos9": "centos9",
 }
 
+// amazonLinuxSeries holds the Amazon Linux series, which are of the RHEL
+// family. They are named after the os-release ID and version, such as
+// "amzn2023".
+var amazonLinuxSeries = map[string]string{
+	"amzn2":    "amzn2",
+	"amzn2023": "amzn2023",
+}
+
+// oracleLinuxSeries holds the Oracle Linux series, which are of the RHEL
+// family. They are named after the major release version, such as
+// "oracle9", in the same way as the CentOS series.
+var oracleLinuxSeries = map[string]string{
+	"oracle8": "oracle8",
+	"oracle9": "oracle9",
+}
+
+// opensuseSeries holds the openSUSE family series, including SUSE Linux
+// Enterprise Server. The SLES series are named after the major release,
+// such as "sles15"; service packs, such as 15.5 for SP5, are updates of the
+// same release rather than new series, in the same way as CentOS minor
+// releases. Tumbleweed is a rolling release, so it is a single series
+// whatever its date based version.
 var opensuseSeries = map[string]string{
-	"opensuseleap": "opensuse42",
+	"opensuseleap":       "opensuse42",
+	"opensusetumbleweed": "opensusetumbleweed",
+	"sles12":             "sles12",
+	"sles15":             "sles15",
+}
+
+// debianSeries holds the Debian series, which are named after the major
+// release version, such as "debian12" for bookworm, in the same way as the
+// CentOS series, rather than after the release codename.
+var debianSeries = map[string]string{
+	"debian10": "debian10",
+	"debian11": "debian11",
+	"debian12": "debian12",
+}
+
+// alpineSeries holds the Alpine series, which are named after the
+// major.minor release version, such as "alpine3.18". Alpine only ships
+// security fixes in patch releases, so they share the series.
+var alpineSeries = map[string]string{
+	"alpine3.17": "alpine3.17",
+	"alpine3.18": "alpine3.18",
+	"alpine3.19": "alpine3.19",
+	"alpine3.20": "alpine3.20",
 }
 
 var kubernetesSeries = map[string]string{
@@ -137,6 +201,14 @@ type SeriesVersionInfo struct {
 	// Extended security maintenance for customers, extends the supported bool
 	// for how Juju classifies the series.
 	ESMSupported bool
+	// ESM indicates that the series is past its end of life, but within
+	// extended security maintenance, according to distro-info when it was
+	// read. Series for which distro-info gives no ESM end of life are never
+	// in ESM.
+	ESM bool
+	// ESMEnd is the end of extended security maintenance given by
+	// distro-info, which is zero if it gives none.
+	ESMEnd time.Time
 	// WarningInfo shows any potential issues when parsing the series version
 	// information.
 	WarningInfo []string
@@ -144,6 +216,9 @@ type SeriesVersionInfo struct {
 	// by the local distro-info information on the system.
 	// This is useful to understand why a version appears yet is not supported.
 	CreatedByLocalDistroInfo bool
+	// CreatedByDistroInfoReader indicates that the series version was
+	// created by the distro-info given to UpdateSeriesVersionsFromReader.
+	CreatedByDistroInfoReader bool
 }
 
 var ubuntuSeries = map[string]SeriesVersionInfo{
@@ -240,8 +315,12 @@ var ubuntuSeries = map[string]SeriesVersionInfo{
 	"noble": {
 		Version:      "24.04",
 		LTS:          true,
-		ESMSupported: false,
-		Supported:    false,
+		Supported:    true,
+		ESMSupported: true,
+	},
+	"oracular": {
+		Version:   "24.10",
+		Supported: false,
 	},
 }
 
@@ -282,6 +361,10 @@ var nonUbuntuSeries = map[string]SeriesVersionInfo{
 		Version:   "win2019",
 		Supported: true,
 	},
+	"win2022": {
+		Version:   "win2022",
+		Supported: true,
+	},
 	"win7": {
 		Version:   "win7",
 		Supported: true,
@@ -298,6 +381,10 @@ var nonUbuntuSeries = map[string]SeriesVersionInfo{
 		Version:   "win10",
 		Supported: true,
 	},
+	"win11": {
+		Version:   "win11",
+		Supported: true,
+	},
 	"centos7": {
 		Version:   "centos7",
 		Supported: true,
@@ -310,10 +397,66 @@ var nonUbuntuSeries = map[string]SeriesVersionInfo{
 		Version:   "centos9",
 		Supported: true,
 	},
+	"amzn2": {
+		Version:   "amzn2",
+		Supported: true,
+	},
+	"amzn2023": {
+		Version:   "amzn2023",
+		Supported: true,
+	},
+	"oracle8": {
+		Version:   "oracle8",
+		Supported: true,
+	},
+	"oracle9": {
+		Version:   "oracle9",
+		Supported: true,
+	},
 	"opensuseleap": {
 		Version:   "opensuse42",
 		Supported: true,
 	},
+	"opensusetumbleweed": {
+		Version:   "opensusetumbleweed",
+		Supported: true,
+	},
+	"sles12": {
+		Version:   "sles12",
+		Supported: true,
+	},
+	"sles15": {
+		Version:   "sles15",
+		Supported: true,
+	},
+	"debian10": {
+		Version:   "debian10",
+		Supported: true,
+	},
+	"debian11": {
+		Version:   "debian11",
+		Supported: true,
+	},
+	"debian12": {
+		Version:   "debian12",
+		Supported: true,
+	},
+	"alpine3.17": {
+		Version:   "alpine3.17",
+		Supported: true,
+	},
+	"alpine3.18": {
+		Version:   "alpine3.18",
+		Supported: true,
+	},
+	"alpine3.19": {
+		Version:   "alpine3.19",
+		Supported: true,
+	},
+	"alpine3.20": {
+		Version:   "alpine3.20",
+		Supported: true,
+	},
 	genericLinuxSeries: {
 		Version:   genericLinuxVersion,
 		Supported: true,
@@ -337,6 +480,7 @@ var windowsVersionMatchOrder = []string{
 	"Windows Server 2012",
 	"Hyper-V Server 2016",
 	"Windows Server 2016",
+	"Windows Server 2022",
 	"Windows Server 2019",
 	"Windows Storage Server 2012 R2",
 	"Windows Storage Server 2012",
@@ -345,6 +489,7 @@ var windowsVersionMatchOrder = []string{
 	"Windows 8.1",
 	"Windows 8",
 	"Windows 10",
+	"Windows 11",
 }
 
 // windowsVersions is a mapping consisting of the output from
@@ -358,6 +503,7 @@ var windowsVersions = map[string]string{
 	"Hyper-V Server 2016":            "win2016hv",
 	"Windows Server 2016":            "win2016",
 	"Windows Server 2019":            "win2019",
+	"Windows Server 2022":            "win2022",
 	"Windows Storage Server 2012 R2": "win2012r2",
 	"Windows Storage Server 2012":    "win2012",
 	"Windows Storage Server 2016":    "win2016",
@@ -366,6 +512,7 @@ var windowsVersions = map[string]string{
 	"Windows 8.1":                    "win81",
 	"Windows 8":                      "win8",
 	"Windows 10":                     "win10",
+	"Windows 11":                     "win11",
 }
 
 // windowsNanoVersions is a mapping from the product name
@@ -417,13 +564,55 @@ func IsWindowsNano(series string) bool {
 	return false
 }
 
+// seriesFormat matches a series that is made up of a lower case name,
+// optionally followed by a version, such as "jammy", "centos7",
+// "win2012hvr2" or "alpine3.18".
+var seriesFormat = regexp.MustCompile(`^([a-z]+)([0-9][a-z0-9.]*)?$`)
+
+// versionedSeriesPrefixes holds the prefixes of the series families whose
+// names carry a version.
+var versionedSeriesPrefixes = map[string]bool{
+	"alpine":   true,
+	"amzn":     true,
+	"centos":   true,
+	"debian":   true,
+	"opensuse": true,
+	"oracle":   true,
+	"sles":     true,
+	"win":      true,
+}
+
+// maxSeriesLength is the length of the longest series that is considered
+// to be well formed.
+const maxSeriesLength = 32
+
+// IsValidSeriesFormat returns true if the series is well formed: it is
+// lower case, contains no spaces and, if it carries a version, starts with
+// the prefix of a known series family. This is a format check only; it
+// doesn't check that the series exists, for that use GetOSFromSeries.
+func IsValidSeriesFormat(series string) bool {
+	if len(series) > maxSeriesLength {
+		return false
+	}
+	match := seriesFormat.FindStringSubmatch(series)
+	if match == nil {
+		return false
+	}
+	if match[2] == "" {
+		return true
+	}
+	return versionedSeriesPrefixes[match[1]]
+}
+
 // GetOSFromSeries will return the operating system based
 // on the series that is passed to it
 func GetOSFromSeries(series string) (os.OSType, error) {
 	if series == "" {
 		return os.Unknown, errors.NotValidf("series %q", series)
 	}
+	seriesVersionsMutex.RLock()
 	osType, err := getOSFromSeries(series)
+	seriesVersionsMutex.RUnlock()
 	if err == nil {
 		return osType, nil
 	}
@@ -442,9 +631,21 @@ func getOSFromSeries(series string) (os.OSType, error) {
 	if _, ok := centosSeries[series]; ok {
 		return os.CentOS, nil
 	}
+	if _, ok := amazonLinuxSeries[series]; ok {
+		return os.CentOS, nil
+	}
+	if _, ok := oracleLinuxSeries[series]; ok {
+		return os.CentOS, nil
+	}
 	if _, ok := opensuseSeries[series]; ok {
 		return os.OpenSUSE, nil
 	}
+	if _, ok := debianSeries[series]; ok {
+		return os.Debian, nil
+	}
+	if _, ok := alpineSeries[series]; ok {
+		return os.Alpine, nil
+	}
 	if _, ok := kubernetesSeries[series]; ok {
 		return os.Kubernetes, nil
 	}
@@ -476,7 +677,9 @@ func GetOSFromSeriesWithBaseOS(series, baseOS string) (os.OSType, error) {
 	if series == "" {
 		return os.Unknown, errors.NotValidf("series %q", series)
 	}
+	seriesVersionsMutex.RLock()
 	osType, err := getOSFromSeriesWithBaseOS(series, baseOS)
+	seriesVersionsMutex.RUnlock()
 	if err == nil {
 		return osType, nil
 	}
@@ -499,7 +702,10 @@ func getOSFromSeriesWithBaseOS(series, baseOS string) (os.OSType, error) {
 }
 
 var (
-	seriesVersionsMutex sync.Mutex
+	// seriesVersionsMutex guards the series version tables, which are
+	// updated from distro-info. Reads that may need the update must hold
+	// the write lock, as updateSeriesVersionsOnce modifies the tables.
+	seriesVersionsMutex sync.RWMutex
 )
 
 // SeriesVersion returns the version for the specified series.
@@ -538,6 +744,173 @@ func UbuntuSeriesVersion(series string) (string, error) {
 	return "", errors.Trace(unknownSeriesVersionError(series))
 }
 
+// UbuntuSeriesInfo returns the version information of the ubuntu series,
+// and whether the series is known. Unlike UbuntuSupportedSeries, only the
+// one series is looked up, and distro-info is only read if the series
+// hasn't been seen yet.
+func UbuntuSeriesInfo(series string) (SeriesVersionInfo, bool) {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	if info, ok := ubuntuSeries[series]; ok {
+		return info, true
+	}
+	updateSeriesVersionsOnce()
+	info, ok := ubuntuSeries[series]
+	return info, ok
+}
+
+// IsESMSeries returns true if the specified ubuntu series is past its end of
+// life, but still within extended security maintenance, according to the
+// local distro-info. Series that distro-info gives no ESM end of life for
+// are not.
+func IsESMSeries(series string) bool {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	updateSeriesVersionsOnce()
+	return ubuntuSeries[series].ESM
+}
+
+// IsLTS returns true if the specified series is an ubuntu LTS series, such
+// as jammy. Series of other operating systems, and unknown series, are not.
+func IsLTS(series string) bool {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	if vers, ok := ubuntuSeries[series]; ok {
+		return vers.LTS
+	}
+	updateSeriesVersionsOnce()
+	return ubuntuSeries[series].LTS
+}
+
+// IsDevelopmentSeries returns true if the specified ubuntu series has not
+// been released yet, according to the local distro-info. Series that aren't
+// found in distro-info, but are otherwise known, are considered released.
+func IsDevelopmentSeries(series string) (bool, error) {
+	return defaultHost.IsDevelopmentSeries(series)
+}
+
+// IsDevelopmentSeries returns true if the specified ubuntu series has not
+// been released yet, according to the host's distro-info.
+func (h *Host) IsDevelopmentSeries(series string) (bool, error) {
+	if series == "" {
+		return false, errors.Trace(unknownSeriesVersionError(""))
+	}
+	distroInfo := NewDistroInfo(h.path(UbuntuDistroInfo))
+	if err := distroInfo.Refresh(); err != nil {
+		return false, errors.Trace(err)
+	}
+	if info, ok := distroInfo.SeriesInfo(series); ok {
+		return info.Development(timeNow().UTC()), nil
+	}
+	if _, err := UbuntuSeriesVersion(series); err != nil {
+		return false, errors.Trace(err)
+	}
+	return false, nil
+}
+
+// esmPeriod is how long extended security maintenance lasts beyond the end
+// of standard support, for ESM series whose distro-info has no eol-esm date.
+const esmPeriod = 5 * 365 * 24 * time.Hour
+
+// SupportStatus returns the support status of the specified ubuntu series,
+// according to the local distro-info: "development" before its release,
+// "supported" until its end of life, "esm" during extended security
+// maintenance and "eol" after that. Series that aren't found in distro-info,
+// including those of other operating systems, are "unknown".
+func SupportStatus(series string) (string, error) {
+	return defaultHost.SupportStatus(series)
+}
+
+// SupportStatus returns the support status of the specified ubuntu series,
+// according to the host's distro-info.
+func (h *Host) SupportStatus(series string) (string, error) {
+	distroInfo := NewDistroInfo(h.path(UbuntuDistroInfo))
+	if err := distroInfo.Refresh(); err != nil {
+		return "unknown", errors.Trace(err)
+	}
+	info, ok := distroInfo.SeriesInfo(series)
+	if !ok {
+		return "unknown", nil
+	}
+	now := timeNow().UTC()
+	switch {
+	case info.Development(now):
+		return "development", nil
+	case info.Supported(now):
+		return "supported", nil
+	}
+	seriesVersionsMutex.RLock()
+	esmSupported := ubuntuSeries[series].ESMSupported
+	seriesVersionsMutex.RUnlock()
+	eolESM := info.EOLESM
+	if eolESM.IsZero() && esmSupported {
+		eolESM = info.EOL.Add(esmPeriod)
+	}
+	if now.Before(eolESM.UTC()) {
+		return "esm", nil
+	}
+	return "eol", nil
+}
+
+// SeriesEOL returns the end of life of the specified ubuntu series,
+// according to the local distro-info. The end of life of the server edition
+// is used where distro-info gives one. A NotFound error is returned for
+// series that aren't found in distro-info.
+func SeriesEOL(series string) (time.Time, error) {
+	return defaultHost.SeriesEOL(series)
+}
+
+// SeriesEOL returns the end of life of the specified ubuntu series,
+// according to the host's distro-info.
+func (h *Host) SeriesEOL(series string) (time.Time, error) {
+	distroInfo := NewDistroInfo(h.path(UbuntuDistroInfo))
+	if err := distroInfo.Refresh(); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	info, ok := distroInfo.SeriesInfo(series)
+	if !ok {
+		return time.Time{}, errors.NotFoundf("end of life of series %q", series)
+	}
+	if !info.EOLServer.IsZero() {
+		return info.EOLServer, nil
+	}
+	return info.EOL, nil
+}
+
+// SimpleStreamsID returns the series in the "os:release" form used by
+// simplestreams metadata, such as "ubuntu:22.04" or "centos:7". Operating
+// systems that don't fit the scheme, such as OSX and Windows, return an
+// error.
+func SimpleStreamsID(series string) (string, error) {
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch osType {
+	case os.Ubuntu:
+		version, err := UbuntuSeriesVersion(series)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return "ubuntu:" + strings.TrimSuffix(version, " LTS"), nil
+	case os.CentOS:
+		// Amazon and Oracle Linux series have no simplestreams id.
+		if version, ok := centosSeries[series]; ok {
+			return "centos:" + strings.TrimPrefix(version, "centos"), nil
+		}
+	case os.OpenSUSE:
+		if version := opensuseSeries[series]; strings.HasPrefix(version, slesID) {
+			return "sles:" + strings.TrimPrefix(version, slesID), nil
+		}
+		return "opensuse:" + strings.TrimPrefix(opensuseSeries[series], "opensuse"), nil
+	case os.Debian:
+		return "debian:" + strings.TrimPrefix(debianSeries[series], "debian"), nil
+	case os.Alpine:
+		return "alpine:" + strings.TrimPrefix(alpineSeries[series], "alpine"), nil
+	}
+	return "", errors.NotSupportedf("simplestreams id for %s series %q", osType, series)
+}
+
 // VersionSeries returns the series (e.g.trusty) for the specified version (e.g. 14.04).
 func VersionSeries(version string) (string, error) {
 	if version == "" {
@@ -555,6 +928,21 @@ func VersionSeries(version string) (string, error) {
 	return "", errors.Trace(unknownVersionSeriesError(version))
 }
 
+// SeriesFromVersion returns the ubuntu series (e.g. jammy) for the
+// specified VERSION_ID (e.g. 22.04). Point releases, such as "20.04.3", and
+// an LTS suffix are ignored, so they resolve to the series of the release.
+func SeriesFromVersion(version string) (string, error) {
+	normalized := strings.TrimSuffix(strings.TrimSpace(version), " LTS")
+	if parts := strings.Split(normalized, "."); len(parts) > 2 {
+		normalized = strings.Join(parts[:2], ".")
+	}
+	series, err := VersionSeries(normalized)
+	if err != nil {
+		return "", errors.Annotatef(err, "version %q", version)
+	}
+	return series, nil
+}
+
 // WindowsVersionSeries returns the series (eg: win2012r2) for the specified version
 // (eg: Windows Server 2012 R2 Standard)
 func WindowsVersionSeries(version string) (string, error) {
@@ -609,12 +997,11 @@ var latestLtsSeries string
 
 // LatestLts returns the Latest LTS Series found in distro-info
 func LatestLts() string {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
 	if latestLtsSeries != "" {
 		return latestLtsSeries
 	}
-
-	seriesVersionsMutex.Lock()
-	defer seriesVersionsMutex.Unlock()
 	updateSeriesVersionsOnce()
 
 	var latest string
@@ -631,11 +1018,113 @@ func LatestLts() string {
 	return latest
 }
 
+// LatestLTS returns the ubuntu LTS series with the highest version out of
+// the known series, including those loaded from distro-info. Unlike
+// LatestLts, it doesn't consider whether Juju supports the series, and it
+// is worked out afresh on each call.
+func LatestLTS() string {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	updateSeriesVersionsOnce()
+
+	var latest, latestVersion string
+	for name, version := range seriesVersions {
+		if !ubuntuSeries[name].LTS {
+			continue
+		}
+		if latest != "" {
+			if c, err := compareVersions(version, latestVersion); err != nil || c < 0 || (c == 0 && name > latest) {
+				continue
+			}
+		}
+		latest, latestVersion = name, version
+	}
+	return latest
+}
+
+// CompareSeries compares two ubuntu series by their release version,
+// returning -1, 0 or 1 if a was released before, at the same time as, or
+// after b; for example bionic is before focal, which is before impish and
+// then jammy. An error satisfying IsUnknownSeriesVersionError is returned
+// if either series isn't a known ubuntu series.
+func CompareSeries(a, b string) (int, error) {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+
+	aVersion, err := ubuntuReleaseVersion(a)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	bVersion, err := ubuntuReleaseVersion(b)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return compareVersions(aVersion, bVersion)
+}
+
+// ubuntuReleaseVersion returns the numeric version of the ubuntu series,
+// such as "22.04" for jammy, updating the series versions from distro-info
+// if the series isn't known. The caller must hold the seriesVersionsMutex.
+func ubuntuReleaseVersion(series string) (string, error) {
+	info, ok := ubuntuSeries[series]
+	if !ok {
+		updateSeriesVersionsOnce()
+		if info, ok = ubuntuSeries[series]; !ok {
+			return "", unknownSeriesVersionError(series)
+		}
+	}
+	return strings.TrimSuffix(info.Version, " LTS"), nil
+}
+
+// ResolveAlias resolves a series alias to the series it currently stands
+// for: "current", "stable" and "latest-lts" resolve to the newest supported
+// LTS, and "latest" to the newest known ubuntu series. Any other known
+// series is returned unchanged.
+func ResolveAlias(input string) (string, error) {
+	switch input {
+	case "current", "stable", "latest-lts":
+		if lts := LatestLts(); lts != "" {
+			return lts, nil
+		}
+		return "", errors.NotFoundf("supported LTS series for %q", input)
+	case "latest":
+		if latest := latestUbuntuSeries(); latest != "" {
+			return latest, nil
+		}
+		return "", errors.NotFoundf("ubuntu series for %q", input)
+	}
+	if _, err := GetOSFromSeries(input); err != nil {
+		return "", errors.Trace(err)
+	}
+	return input, nil
+}
+
+// latestUbuntuSeries returns the ubuntu series with the highest version.
+func latestUbuntuSeries() string {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	updateSeriesVersionsOnce()
+
+	var latest, latestVersion string
+	for name, info := range ubuntuSeries {
+		version := strings.TrimSuffix(info.Version, " LTS")
+		if latest != "" {
+			if c, err := compareVersions(version, latestVersion); err != nil || c < 0 || (c == 0 && name > latest) {
+				continue
+			}
+		}
+		latest, latestVersion = name, version
+	}
+	return latest
+}
+
 // SetLatestLtsForTesting is provided to allow tests to override the lts series
 // used and decouple the tests from the host by avoiding calling out to
 // distro-info.  It returns the previous setting so that it may be set back to
 // the original value by the caller.
 func SetLatestLtsForTesting(series string) string {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
 	old := latestLtsSeries
 	latestLtsSeries = series
 	return old
@@ -667,6 +1156,43 @@ func SupportedSeries() []string {
 	return series
 }
 
+// AllKnownSeries returns every series known to this package, sorted by
+// name: the compiled-in series of each operating system, including macOS,
+// along with any ubuntu series loaded from distro-info.
+func AllKnownSeries() []string {
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	updateSeriesVersionsOnce()
+
+	known := map[string]bool{genericLinuxSeries: true}
+	for _, names := range []map[string]string{
+		seriesVersions, centosSeries, amazonLinuxSeries, oracleLinuxSeries,
+		opensuseSeries, debianSeries, alpineSeries, kubernetesSeries,
+	} {
+		for name := range names {
+			known[name] = true
+		}
+	}
+	for name := range ubuntuSeries {
+		known[name] = true
+	}
+	for _, versions := range []map[string]string{windowsVersions, windowsNanoVersions} {
+		for _, name := range versions {
+			known[name] = true
+		}
+	}
+	for _, name := range macOSXSeries {
+		known[name] = true
+	}
+
+	series := make([]string, 0, len(known))
+	for name := range known {
+		series = append(series, name)
+	}
+	sort.Strings(series)
+	return series
+}
+
 type namedSeriesVersion struct {
 	Name          string
 	SeriesVersion SeriesVersionInfo
@@ -771,6 +1297,27 @@ func SupportedJujuSeries() []string {
 	return SupportedJujuWorkloadSeries()
 }
 
+// ControllerSeries returns the series that a controller may be deployed on:
+// the supported ubuntu LTS series, sorted in release version. Interim
+// releases are left out, as controllers are only deployed on an LTS.
+func ControllerSeries() []string {
+	var series []string
+	for _, version := range ubuntuSeriesSortedByVersion() {
+		if !version.SeriesVersion.Supported || !version.SeriesVersion.LTS {
+			continue
+		}
+		series = append(series, version.Name)
+	}
+	return series
+}
+
+// WorkloadSeries returns the series that a workload may be deployed on:
+// every supported series, including ubuntu interim releases. The series are
+// sorted as for SupportedJujuWorkloadSeries.
+func WorkloadSeries() []string {
+	return SupportedJujuWorkloadSeries()
+}
+
 // ESMSupportedJujuSeries returns a slice of just juju extended security
 // maintenance supported ubuntu series.
 // The series are sorted in release version.
@@ -807,6 +1354,111 @@ func OSSupportedSeries(os os.OSType) []string {
 	return osSeries
 }
 
+// SeriesInVersionRange returns the series of the specified OS whose versions
+// fall within the inclusive range [min, max], sorted by version. Ubuntu
+// versions are of the form "22.04", whilst CentOS versions are the major
+// version, such as "7".
+func SeriesInVersionRange(osType os.OSType, min, max string) ([]string, error) {
+	if _, err := parseVersion(min); err != nil {
+		return nil, errors.NotValidf("min version %q", min)
+	}
+	if _, err := parseVersion(max); err != nil {
+		return nil, errors.NotValidf("max version %q", max)
+	}
+	if c, _ := compareVersions(min, max); c > 0 {
+		return nil, errors.NotValidf("version range %q to %q", min, max)
+	}
+
+	versions := make(map[string]string)
+	switch osType {
+	case os.Ubuntu:
+		seriesVersionsMutex.Lock()
+		updateSeriesVersionsOnce()
+		for name, info := range ubuntuSeries {
+			versions[name] = strings.TrimSuffix(info.Version, " LTS")
+		}
+		seriesVersionsMutex.Unlock()
+	case os.CentOS:
+		for name := range centosSeries {
+			versions[name] = strings.TrimPrefix(name, "centos")
+		}
+	default:
+		return nil, errors.NotSupportedf("version ranges for %s", osType)
+	}
+
+	type namedVersion struct {
+		name, version string
+	}
+	var inRange []namedVersion
+	for name, version := range versions {
+		if c, err := compareVersions(version, min); err != nil || c < 0 {
+			continue
+		}
+		if c, err := compareVersions(version, max); err != nil || c > 0 {
+			continue
+		}
+		inRange = append(inRange, namedVersion{name: name, version: version})
+	}
+	sort.Slice(inRange, func(i, j int) bool {
+		c, _ := compareVersions(inRange[i].version, inRange[j].version)
+		if c != 0 {
+			return c < 0
+		}
+		return inRange[i].name < inRange[j].name
+	})
+
+	result := make([]string, len(inRange))
+	for i, v := range inRange {
+		result[i] = v.name
+	}
+	return result, nil
+}
+
+// parseVersion parses a dotted numeric version, such as "22.04" or "7",
+// into its components.
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	result := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// compareVersions compares two dotted numeric versions, returning -1, 0 or 1
+// if a is less than, equal to or greater than b. Missing components are
+// treated as zero.
+func compareVersions(a, b string) (int, error) {
+	av, err := parseVersion(a)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	bv, err := parseVersion(b)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for i := 0; i < len(av) || i < len(bv); i++ {
+		var x, y int
+		if i < len(av) {
+			x = av[i]
+		}
+		if i < len(bv) {
+			y = bv[i]
+		}
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
 // UpdateSeriesVersions forces an update of the series versions by querying
 // distro-info if possible.
 func UpdateSeriesVersions() error {
@@ -822,6 +1474,64 @@ func UpdateSeriesVersions() error {
 	return nil
 }
 
+// UpdateSeriesVersionsFromReader merges the series in the distro-info CSV
+// read from r, in the format of /usr/share/distro-info/ubuntu.csv, into the
+// known series versions. This allows new series to be known without access
+// to a local distro-info file, such as from a copy fetched from elsewhere.
+func UpdateSeriesVersionsFromReader(r io.Reader) error {
+	info, err := readDistroInfo(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+
+	mergeDistroInfo(info, func(info *SeriesVersionInfo) {
+		info.CreatedByDistroInfoReader = true
+	})
+	updateVersionSeries()
+	latestLtsSeries = ""
+	return nil
+}
+
+// mergeDistroInfo merges the distro-info series into seriesVersions and
+// ubuntuSeries. Series that are already in ubuntuSeries are kept, except to
+// update their supported status, and new series are passed to mark so that
+// their source can be recorded. The caller must hold the
+// seriesVersionsMutex.
+func mergeDistroInfo(info map[string]DistroInfoSerie, mark func(*SeriesVersionInfo)) {
+	now := timeNow().UTC()
+
+	for seriesName, version := range info {
+		// The numeric version may contain a LTS moniker so strip that out.
+		trimmedVersion := strings.TrimSuffix(version.Version, " LTS")
+		seriesVersions[seriesName] = trimmedVersion
+
+		// If the series already exists inside of ubuntuSeries then don't
+		// overwrite that existing one, except to update the supported status.
+		supported := version.Supported(now)
+
+		if us, ok := ubuntuSeries[seriesName]; ok {
+			us.Supported = us.Supported && supported
+			us.ESM = version.ESM(now)
+			us.ESMEnd = version.EOLESM
+			ubuntuSeries[seriesName] = us
+			continue
+		}
+
+		created := SeriesVersionInfo{
+			Version:   version.Version,
+			Supported: false,
+			LTS:       version.LTS(),
+			ESM:       version.ESM(now),
+			ESMEnd:    version.EOLESM,
+		}
+		mark(&created)
+		ubuntuSeries[seriesName] = created
+	}
+}
+
 var updatedseriesVersions bool
 
 func updateSeriesVersionsOnce() {
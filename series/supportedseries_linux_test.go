@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -36,7 +37,7 @@ func (s *supportedSeriesSuite) TestSupportedSeries(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"artful", "bionic", "cosmic", "disco", "eoan", "focal", "groovy", "hirsute", "impish", "jammy", "precise", "quantal", "raring", "saucy", "trusty", "utopic", "vivid", "wily", "xenial", "yakkety", "zesty"}
+	expectedSeries := []string{"artful", "bionic", "cosmic", "disco", "eoan", "focal", "groovy", "hirsute", "impish", "jammy", "kinetic", "lunar", "mantic", "noble", "oracular", "precise", "quantal", "raring", "saucy", "trusty", "utopic", "vivid", "wily", "xenial", "yakkety", "zesty"}
 	series := series.SupportedSeries()
 	sort.Strings(series)
 	c.Assert(series, gc.DeepEquals, expectedSeries)
@@ -49,7 +50,7 @@ func (s *supportedSeriesSuite) TestUpdateSeriesVersions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"artful", "bionic", "cosmic", "disco", "eoan", "focal", "groovy", "hirsute", "impish", "jammy", "precise", "quantal", "raring", "saucy", "trusty", "utopic", "vivid", "wily", "xenial", "yakkety", "zesty"}
+	expectedSeries := []string{"artful", "bionic", "cosmic", "disco", "eoan", "focal", "groovy", "hirsute", "impish", "jammy", "kinetic", "lunar", "mantic", "noble", "oracular", "precise", "quantal", "raring", "saucy", "trusty", "utopic", "vivid", "wily", "xenial", "yakkety", "zesty"}
 	checkSeries := func() {
 		series := series.SupportedSeries()
 		sort.Strings(series)
@@ -70,6 +71,22 @@ func (s *supportedSeriesSuite) TestUpdateSeriesVersions(c *gc.C) {
 	checkSeries()
 }
 
+func (s *supportedSeriesSuite) TestUbuntuSeriesInfoFromDistroInfo(c *gc.C) {
+	d := c.MkDir()
+	filename := filepath.Join(d, "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distInfoData2), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+
+	info, ok := series.UbuntuSeriesInfo("ornery")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(info, jc.DeepEquals, series.SeriesVersionInfo{
+		Version:                  "94.04 LTS",
+		LTS:                      true,
+		CreatedByLocalDistroInfo: true,
+	})
+}
+
 func (s *supportedSeriesSuite) TestLocalSeriesVersionInfo(c *gc.C) {
 	d := c.MkDir()
 	filename := filepath.Join(d, "ubuntu.csv")
@@ -100,7 +117,7 @@ func (s *supportedSeriesSuite) TestESMSupportedJujuSeries(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"jammy", "focal", "bionic", "xenial", "trusty"}
+	expectedSeries := []string{"noble", "jammy", "focal", "bionic", "xenial", "trusty"}
 	series := series.ESMSupportedJujuSeries()
 	c.Assert(series, jc.DeepEquals, expectedSeries)
 }
@@ -127,7 +144,7 @@ func (s *supportedSeriesSuite) TestSupportedJujuControllerSeries(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"focal"}
+	expectedSeries := []string{"groovy", "focal"}
 	series := series.SupportedJujuControllerSeries()
 	c.Assert(series, jc.DeepEquals, expectedSeries)
 }
@@ -139,7 +156,7 @@ func (s *supportedSeriesSuite) TestSupportedJujuWorkloadSeries(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"focal", "centos7", "centos8", "centos9", "genericlinux", "kubernetes", "opensuseleap", "win10", "win2008r2", "win2012", "win2012hv", "win2012hvr2", "win2012r2", "win2016", "win2016hv", "win2016nano", "win2019", "win7", "win8", "win81"}
+	expectedSeries := []string{"groovy", "focal", "alpine3.17", "alpine3.18", "alpine3.19", "alpine3.20", "amzn2", "amzn2023", "centos7", "centos8", "centos9", "debian10", "debian11", "debian12", "genericlinux", "kubernetes", "opensuseleap", "opensusetumbleweed", "oracle8", "oracle9", "sles12", "sles15", "win10", "win11", "win2008r2", "win2012", "win2012hv", "win2012hvr2", "win2012r2", "win2016", "win2016hv", "win2016nano", "win2019", "win2022", "win7", "win8", "win81"}
 	series := series.SupportedJujuWorkloadSeries()
 	c.Assert(series, jc.DeepEquals, expectedSeries)
 }
@@ -151,11 +168,32 @@ func (s *supportedSeriesSuite) TestSupportedJujuSeries(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"focal", "centos7", "centos8", "centos9", "genericlinux", "kubernetes", "opensuseleap", "win10", "win2008r2", "win2012", "win2012hv", "win2012hvr2", "win2012r2", "win2016", "win2016hv", "win2016nano", "win2019", "win7", "win8", "win81"}
+	expectedSeries := []string{"groovy", "focal", "alpine3.17", "alpine3.18", "alpine3.19", "alpine3.20", "amzn2", "amzn2023", "centos7", "centos8", "centos9", "debian10", "debian11", "debian12", "genericlinux", "kubernetes", "opensuseleap", "opensusetumbleweed", "oracle8", "oracle9", "sles12", "sles15", "win10", "win11", "win2008r2", "win2012", "win2012hv", "win2012hvr2", "win2012r2", "win2016", "win2016hv", "win2016nano", "win2019", "win2022", "win7", "win8", "win81"}
 	series := series.SupportedJujuSeries()
 	c.Assert(series, jc.DeepEquals, expectedSeries)
 }
 
+func (s *supportedSeriesSuite) TestControllerAndWorkloadSeries(c *gc.C) {
+	d := c.MkDir()
+	filename := filepath.Join(d, "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distInfoData), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+
+	controller := series.ControllerSeries()
+	c.Assert(controller, jc.DeepEquals, []string{"focal"})
+
+	workload := series.WorkloadSeries()
+	c.Assert(workload, jc.DeepEquals, series.SupportedJujuWorkloadSeries())
+
+	// The interim groovy is supported for workloads, but not controllers.
+	c.Check(set.NewStrings(workload...).Contains("groovy"), jc.IsTrue)
+	c.Check(set.NewStrings(controller...).Contains("groovy"), jc.IsFalse)
+	for _, name := range controller {
+		c.Check(set.NewStrings(workload...).Contains(name), jc.IsTrue)
+	}
+}
+
 func (s *supportedSeriesSuite) TestLatestLts(c *gc.C) {
 	table := []struct {
 		latest, want string
@@ -170,6 +208,24 @@ func (s *supportedSeriesSuite) TestLatestLts(c *gc.C) {
 	}
 }
 
+func (s *supportedSeriesSuite) TestLatestLTSFromDistroInfo(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distInfoData2), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+
+	c.Assert(series.LatestLTS(), gc.Equals, "ornery")
+}
+
+func (s *supportedSeriesSuite) TestAllKnownSeriesFromDistroInfo(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distInfoData2), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+
+	c.Assert(set.NewStrings(series.AllKnownSeries()...).Contains("ornery"), jc.IsTrue)
+}
+
 func (s *supportedSeriesSuite) TestSetLatestLtsForTesting(c *gc.C) {
 	table := []struct {
 		value, want string
@@ -188,6 +244,72 @@ func (s *supportedSeriesSuite) TestSupportedLts(c *gc.C) {
 	c.Assert(got, gc.DeepEquals, want)
 }
 
+func (s *supportedSeriesSuite) TestResolveAlias(c *gc.C) {
+	restore := series.HideUbuntuSeries()
+	defer restore()
+
+	d := c.MkDir()
+	filename := filepath.Join(d, "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distInfoData2), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+	latestLts := series.SetLatestLtsForTesting("jammy")
+	defer series.SetLatestLtsForTesting(latestLts)
+
+	for _, test := range []struct {
+		input    string
+		expected string
+	}{
+		{"current", "jammy"},
+		{"stable", "jammy"},
+		{"latest-lts", "jammy"},
+		{"latest", "ornery"},
+		{"focal", "focal"},
+		{"centos7", "centos7"},
+	} {
+		resolved, err := series.ResolveAlias(test.input)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(resolved, gc.Equals, test.expected, gc.Commentf("%s", test.input))
+	}
+}
+
+func (s *supportedSeriesSuite) TestResolveAliasUnknown(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	_, err := series.ResolveAlias("newest")
+	c.Assert(err, gc.ErrorMatches, `unknown OS for series: "newest"`)
+}
+
+func (s *supportedSeriesSuite) TestIsDevelopmentSeries(c *gc.C) {
+	d := c.MkDir()
+	filename := filepath.Join(d, "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distInfoData), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+
+	// The suite pins the time to 2020-11-01, before hirsute was released.
+	for _, test := range []struct {
+		series   string
+		expected bool
+	}{
+		{"hirsute", true},
+		{"impish", true},
+		{"groovy", false},
+		{"focal", false},
+		{"noble", true},
+	} {
+		c.Logf("series %q", test.series)
+		devel, err := series.IsDevelopmentSeries(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(devel, gc.Equals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestIsDevelopmentSeriesUnknown(c *gc.C) {
+	_, err := series.IsDevelopmentSeries("firewolf")
+	c.Assert(err, gc.ErrorMatches, `.*unknown version for series: "firewolf".*`)
+}
+
 const distInfoData = `version,codename,series,created,release,eol,eol-server,eol-esm
 4.10,Warty Warthog,warty,2004-03-05,2004-10-20,2006-04-30
 5.04,Hoary Hedgehog,hoary,2004-10-20,2005-04-08,2006-10-31
@@ -225,6 +347,11 @@ const distInfoData = `version,codename,series,created,release,eol,eol-server,eol
 21.04,Hirsute Hippo,hirsute,2020-10-22,2021-04-22,2022-01-20
 21.10,Impish Indri,impish,2021-04-22,2021-10-14,2022-07-14
 22.04 LTS,Jammy Jellyfish,jammy,2021-10-14,2022-04-21,2027-04-21,2027-04-21,2032-04-21
+22.10,Kinetic Kudu,kinetic,2022-04-21,2022-10-20,2023-07-20
+23.04,Lunar Lobster,lunar,2022-10-20,2023-04-20,2024-01-20
+23.10,Mantic Minotaur,mantic,2023-04-20,2023-10-12,2024-07-12
+24.04 LTS,Noble Numbat,noble,2023-10-12,2024-04-25,2029-05-31,2029-05-31,2034-04-25
+24.10,Oracular Oriole,oracular,2024-04-25,2024-10-10,2025-07-10
 `
 
 const distInfoData2 = distInfoData + `
@@ -243,8 +370,27 @@ func (s *isolationSupportedSeriesSuite) TestBadFilePath(c *gc.C) {
 	filename := filepath.Join(d, "bad-file.csv")
 	s.PatchValue(series.UbuntuDistroInfoPath, filename)
 
-	expectedSeries := []string{"artful", "bionic", "centos7", "centos8", "centos9", "cosmic", "disco", "eoan", "focal", "genericlinux", "groovy", "hirsute", "impish", "jammy", "kinetic", "lunar", "mantic", "noble", "opensuseleap", "precise", "quantal", "raring", "saucy", "trusty", "utopic", "vivid", "wily", "win10", "win2008r2", "win2012", "win2012hv", "win2012hvr2", "win2012r2", "win2016", "win2016hv", "win2016nano", "win2019", "win7", "win8", "win81", "xenial", "yakkety", "zesty"}
+	expectedSeries := []string{"alpine3.17", "alpine3.18", "alpine3.19", "alpine3.20", "amzn2", "amzn2023", "artful", "bionic", "centos7", "centos8", "centos9", "cosmic", "debian10", "debian11", "debian12", "disco", "eoan", "focal", "genericlinux", "groovy", "hirsute", "impish", "jammy", "kinetic", "lunar", "mantic", "noble", "opensuseleap", "opensusetumbleweed", "oracle8", "oracle9", "oracular", "precise", "quantal", "raring", "saucy", "sles12", "sles15", "trusty", "utopic", "vivid", "wily", "win10", "win11", "win2008r2", "win2012", "win2012hv", "win2012hvr2", "win2012r2", "win2016", "win2016hv", "win2016nano", "win2019", "win2022", "win7", "win8", "win81", "xenial", "yakkety", "zesty"}
 	series := series.SupportedSeries()
 	sort.Strings(series)
 	c.Assert(series, gc.DeepEquals, expectedSeries)
 }
+
+func (s *supportedSeriesSuite) TestNobleWithoutDistroInfo(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	osType, err := series.GetOSFromSeries("noble")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, os.Ubuntu)
+
+	ubuntuSeries := series.UbuntuSupportedSeries()
+	c.Check(ubuntuSeries["noble"], jc.DeepEquals, series.SeriesVersionInfo{
+		Version:      "24.04",
+		LTS:          true,
+		Supported:    true,
+		ESMSupported: true,
+	})
+	c.Check(ubuntuSeries["oracular"], jc.DeepEquals, series.SeriesVersionInfo{
+		Version: "24.10",
+	})
+}
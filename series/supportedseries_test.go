@@ -4,8 +4,13 @@
 package series_test
 
 import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -25,6 +30,8 @@ func (s *supportedSeriesSuite) SetUpTest(c *gc.C) {
 
 	cleanup := series.SetSeriesVersions(make(map[string]string))
 	s.AddCleanup(func(*gc.C) { cleanup() })
+	restore := series.ResetUbuntuSeries()
+	s.AddCleanup(func(*gc.C) { restore() })
 
 	s.PatchValue(series.TimeNow, func() time.Time {
 		return time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
@@ -47,12 +54,38 @@ var getOSFromSeriesTests = []struct {
 }, {
 	series: "mountainlion",
 	want:   os.OSX,
+}, {
+	series: "sequoia",
+	want:   os.OSX,
 }, {
 	series: "centos7",
 	want:   os.CentOS,
 }, {
 	series: "opensuseleap",
 	want:   os.OpenSUSE,
+}, {
+	series: "amzn2",
+	want:   os.CentOS,
+}, {
+	series: "amzn2023",
+	want:   os.CentOS,
+}, {
+	series: "oracle8",
+	want:   os.CentOS,
+}, {
+	series: "oracle9",
+	want:   os.CentOS,}, {
+	series: "sles15",
+	want:   os.OpenSUSE,
+}, {
+	series: "opensusetumbleweed",
+	want:   os.OpenSUSE,
+}, {
+	series: "debian12",
+	want:   os.Debian,
+}, {
+	series: "alpine3.18",
+	want:   os.Alpine,
 }, {
 	series: "kubernetes",
 	want:   os.Kubernetes,
@@ -77,6 +110,14 @@ func (s *supportedSeriesSuite) TestGetOSFromSeries(c *gc.C) {
 	}
 }
 
+func (s *supportedSeriesSuite) TestGetOSFromSeriesRHELFamily(c *gc.C) {
+	for _, name := range []string{"centos9", "amzn2", "amzn2023", "oracle8", "oracle9"} {
+		osType, err := series.GetOSFromSeries(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(osType.Family(), gc.Equals, os.RHELFamily)
+	}
+}
+
 func (s *supportedSeriesSuite) TestUnknownOSFromSeries(c *gc.C) {
 	_, err := series.GetOSFromSeries("Xuanhuaceratops")
 	c.Assert(err, jc.Satisfies, series.IsUnknownOSForSeriesError)
@@ -151,6 +192,110 @@ func setSeriesTestData() {
 	})
 }
 
+func (s *supportedSeriesSuite) TestLatestLTS(c *gc.C) {
+	for i, test := range []struct {
+		versions map[string]string
+		expected string
+	}{{
+		versions: map[string]string{
+			"focal":    "20.04",
+			"jammy":    "22.04",
+			"mantic":   "23.10",
+			"noble":    "24.04",
+			"oracular": "24.10",
+			"centos9":  "centos9",
+		},
+		expected: "noble",
+	}, {
+		versions: map[string]string{
+			"bionic":  "18.04",
+			"focal":   "20.04",
+			"kinetic": "22.10",
+			"jammy":   "22.04",
+		},
+		expected: "jammy",
+	}, {
+		versions: map[string]string{
+			"trusty": "14.04",
+			"xenial": "16.04",
+		},
+		expected: "xenial",
+	}, {
+		versions: map[string]string{
+			"mantic":  "23.10",
+			"centos9": "centos9",
+		},
+		expected: "",
+	}} {
+		c.Logf("%d: %v", i, test.versions)
+		restore := series.SetSeriesVersions(test.versions)
+		c.Check(series.LatestLTS(), gc.Equals, test.expected)
+		restore()
+	}
+}
+
+func (s *supportedSeriesSuite) TestAllKnownSeries(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	known := series.AllKnownSeries()
+	c.Check(sort.StringsAreSorted(known), jc.IsTrue)
+	seen := make(map[string]bool)
+	for _, name := range known {
+		c.Check(seen[name], jc.IsFalse, gc.Commentf("duplicate series %q", name))
+		seen[name] = true
+	}
+	for _, name := range []string{
+		"precise", "jammy", "noble",
+		"centos7", "centos9", "amzn2", "amzn2023",
+		"oracle8", "oracle9",
+		"opensuseleap", "opensusetumbleweed", "sles12", "sles15",
+		"debian12", "alpine3.18",
+		"mountainlion", "sequoia",
+		"win2012r2", "win2016nano",
+		"genericlinux", "kubernetes",
+	} {
+		c.Check(seen[name], jc.IsTrue, gc.Commentf("missing series %q", name))
+	}
+}
+
+// TestConcurrentSetSeriesVersions reads the series versions while they are
+// being replaced; run it with -race to check the locking.
+func (s *supportedSeriesSuite) TestConcurrentSetSeriesVersions(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, _ = series.GetOSFromSeries("jammy")
+				_, _ = series.SeriesVersion("focal")
+				_ = series.SupportedSeries()
+				_ = series.UbuntuSupportedSeries()
+				_ = series.LatestLts()
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		restore := series.SetSeriesVersions(map[string]string{"jammy": "22.04"})
+		series.SetLatestLtsForTesting("")
+		restore()
+	}
+	close(done)
+	wg.Wait()
+
+	osType, err := series.GetOSFromSeries("jammy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, os.Ubuntu)
+}
+
 func (s *supportedSeriesSuite) TestOSSupportedSeries(c *gc.C) {
 	setSeriesTestData()
 	supported := series.OSSupportedSeries(os.Ubuntu)
@@ -217,3 +362,374 @@ func (s *supportedSeriesSuite) TestUbuntuInvalidSeriesVersion(c *gc.C) {
 	_, err := series.UbuntuSeriesVersion("firewolf")
 	c.Assert(err, gc.ErrorMatches, `.*unknown version for series: "firewolf".*`)
 }
+
+func (s *supportedSeriesSuite) TestIsESMSeries(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+	err := series.UpdateSeriesVersionsFromReader(strings.NewReader(
+		"version,codename,series,created,release,eol,eol-server,eol-esm\n" +
+			"12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26,2017-04-28,2019-04-26\n" +
+			"14.04 LTS,Trusty Tahr,trusty,2013-10-17,2014-04-17,2019-04-25,,2024-04-25\n" +
+			"16.04 LTS,Xenial Xerus,xenial,2015-10-22,2016-04-21,2021-04-21,,2026-04-23\n" +
+			"18.10,Cosmic Cuttlefish,cosmic,2018-04-26,2018-10-18,2019-07-18\n" +
+			"94.04 LTS,Ornery Omega,ornery,2089-10-17,2090-04-17,2095-04-17,,2100-04-17\n"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		series   string
+		expected bool
+	}{
+		// Past its end of life, within ESM.
+		{"trusty", true},
+		// Past its end of life and ESM.
+		{"precise", false},
+		// Not yet at its end of life.
+		{"xenial", false},
+		// Past its end of life, without ESM.
+		{"cosmic", false},
+		// Not yet released.
+		{"ornery", false},
+		// Not in distro-info.
+		{"focal", false},
+		{"centos9", false},
+		{"firewolf", false},
+	} {
+		c.Logf("%d: %s", i, test.series)
+		c.Check(series.IsESMSeries(test.series), gc.Equals, test.expected)
+	}
+
+	info, _ := series.UbuntuSeriesInfo("trusty")
+	c.Check(info.ESMEnd.Equal(time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC)), jc.IsTrue)
+	info, _ = series.UbuntuSeriesInfo("cosmic")
+	c.Check(info.ESMEnd.IsZero(), jc.IsTrue)
+}
+
+func (s *supportedSeriesSuite) TestIsLTS(c *gc.C) {
+	for _, test := range []struct {
+		series   string
+		expected bool
+	}{
+		{"trusty", true},
+		{"focal", true},
+		{"jammy", true},
+		{"noble", true},
+		{"mantic", false},
+		{"oracular", false},
+		{"centos9", false},
+		{"win2019", false},
+		{"genericlinux", false},
+		{"firewolf", false},
+		{"", false},
+	} {
+		c.Logf("series %q", test.series)
+		c.Check(series.IsLTS(test.series), gc.Equals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestUbuntuSeriesInfo(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	info, ok := series.UbuntuSeriesInfo("jammy")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(info, jc.DeepEquals, series.SeriesVersionInfo{
+		Version:      "22.04",
+		LTS:          true,
+		Supported:    true,
+		ESMSupported: true,
+	})
+
+	info, ok = series.UbuntuSeriesInfo("precise")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(info, jc.DeepEquals, series.SeriesVersionInfo{
+		Version: "12.04",
+	})
+
+	for _, name := range []string{"firewolf", "centos9", ""} {
+		c.Logf("series %q", name)
+		info, ok = series.UbuntuSeriesInfo(name)
+		c.Check(ok, jc.IsFalse)
+		c.Check(info, jc.DeepEquals, series.SeriesVersionInfo{})
+	}
+}
+
+func (s *supportedSeriesSuite) TestCompareSeries(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	for i, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"bionic", "focal", -1},
+		{"focal", "jammy", -1},
+		{"jammy", "noble", -1},
+		{"impish", "jammy", -1},
+		{"focal", "impish", -1},
+		{"jammy", "impish", 1},
+		{"noble", "mantic", 1},
+		{"oracular", "noble", 1},
+		{"trusty", "xenial", -1},
+		{"jammy", "jammy", 0},
+	} {
+		c.Logf("%d: %s %s", i, test.a, test.b)
+		result, err := series.CompareSeries(test.a, test.b)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestCompareSeriesNotUbuntu(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	for i, test := range []struct {
+		a, b string
+		err  string
+	}{
+		{"jammy", "centos9", `unknown version for series: "centos9"`},
+		{"win2019", "jammy", `unknown version for series: "win2019"`},
+		{"genericlinux", "focal", `unknown version for series: "genericlinux"`},
+		{"jammy", "firewolf", `unknown version for series: "firewolf"`},
+		{"", "jammy", `unknown version for series: ""`},
+	} {
+		c.Logf("%d: %s %s", i, test.a, test.b)
+		_, err := series.CompareSeries(test.a, test.b)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(series.IsUnknownSeriesVersionError(err), jc.IsTrue)
+	}
+}
+
+func (s *supportedSeriesSuite) TestSeriesInVersionRange(c *gc.C) {
+	for i, test := range []struct {
+		os       os.OSType
+		min, max string
+		expected []string
+	}{
+		{os.Ubuntu, "20.04", "22.04", []string{"focal", "groovy", "hirsute", "impish", "jammy"}},
+		{os.Ubuntu, "22.04", "22.04", []string{"jammy"}},
+		{os.Ubuntu, "9.04", "12.04", []string{"precise"}},
+		{os.CentOS, "7", "8", []string{"centos7", "centos8"}},
+		{os.CentOS, "10", "11", []string{}},
+	} {
+		c.Logf("%d: %s [%s, %s]", i, test.os, test.min, test.max)
+		got, err := series.SeriesInVersionRange(test.os, test.min, test.max)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(got, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestSeriesInVersionRangeErrors(c *gc.C) {
+	_, err := series.SeriesInVersionRange(os.Ubuntu, "focal", "22.04")
+	c.Assert(err, gc.ErrorMatches, `min version "focal" not valid`)
+	_, err = series.SeriesInVersionRange(os.Ubuntu, "20.04", "")
+	c.Assert(err, gc.ErrorMatches, `max version "" not valid`)
+	_, err = series.SeriesInVersionRange(os.Ubuntu, "22.04", "20.04")
+	c.Assert(err, gc.ErrorMatches, `version range "22.04" to "20.04" not valid`)
+	_, err = series.SeriesInVersionRange(os.OSX, "10", "11")
+	c.Assert(err, gc.ErrorMatches, `version ranges for OSX not supported`)
+}
+
+func (s *supportedSeriesSuite) TestSimpleStreamsID(c *gc.C) {
+	for _, test := range []struct {
+		series   string
+		expected string
+	}{
+		{"jammy", "ubuntu:22.04"},
+		{"focal", "ubuntu:20.04"},
+		{"centos7", "centos:7"},
+		{"centos9", "centos:9"},
+		{"opensuseleap", "opensuse:42"},
+		{"sles15", "sles:15"},
+		{"opensusetumbleweed", "opensuse:tumbleweed"},
+		{"debian11", "debian:11"},
+		{"alpine3.18", "alpine:3.18"},
+	} {
+		id, err := series.SimpleStreamsID(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(id, gc.Equals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestSimpleStreamsIDNotSupported(c *gc.C) {
+	_, err := series.SimpleStreamsID("sonoma")
+	c.Assert(err, gc.ErrorMatches, `simplestreams id for OSX series "sonoma" not supported`)
+	_, err = series.SimpleStreamsID("win2019")
+	c.Assert(err, gc.ErrorMatches, `simplestreams id for Windows series "win2019" not supported`)
+	_, err = series.SimpleStreamsID("amzn2023")
+	c.Assert(err, gc.ErrorMatches, `simplestreams id for CentOS series "amzn2023" not supported`)
+	_, err = series.SimpleStreamsID("firewolf")
+	c.Assert(err, jc.Satisfies, series.IsUnknownOSForSeriesError)
+}
+
+func (s *supportedSeriesSuite) TestIsValidSeriesFormat(c *gc.C) {
+	for _, test := range []struct {
+		series   string
+		expected bool
+	}{
+		{"jammy", true},
+		{"centos7", true},
+		{"amzn2023", true},
+		{"oracle9", true},
+		{"win2012hvr2", true},
+		{"genericlinux", true},
+		{"alpine3.18", true},
+		{"notarealseries", true},
+		{"Jammy Jellyfish", false},
+		{"Jammy", false},
+		{"jammy ", false},
+		{"22.04", false},
+		{"foo7", false},
+		{"", false},
+		{"averyveryveryveryverylongseriesname", false},
+	} {
+		c.Logf("series %q", test.series)
+		c.Check(series.IsValidSeriesFormat(test.series), gc.Equals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestSupportStatus(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		series.UbuntuDistroInfo: "version,codename,series,created,release,eol,eol-server,eol-esm\n" +
+			"12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26,2017-04-28,2019-04-26\n" +
+			"16.04 LTS,Xenial Xerus,xenial,2015-10-22,2016-04-21,2021-04-21\n" +
+			"18.04 LTS,Bionic Beaver,bionic,2017-10-19,2018-04-26,2023-05-31,2023-05-31,2028-04-26\n" +
+			"18.10,Cosmic Cuttlefish,cosmic,2018-04-26,2018-10-18,2019-07-18\n",
+	})
+	h := &series.Host{Root: root}
+	for i, test := range []struct {
+		series   string
+		now      time.Time
+		expected string
+	}{
+		{"bionic", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "development"},
+		{"bionic", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "supported"},
+		{"bionic", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "esm"},
+		{"bionic", time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC), "eol"},
+		{"cosmic", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "eol"},
+		// Without an eol-esm date, ESM series get five years of ESM.
+		{"xenial", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "esm"},
+		{"xenial", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), "eol"},
+		{"precise", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "eol"},
+		{"firewolf", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "unknown"},
+		{"centos7", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "unknown"},
+	} {
+		c.Logf("%d: %s at %s", i, test.series, test.now)
+		now := test.now
+		s.PatchValue(series.TimeNow, func() time.Time { return now })
+
+		status, err := h.SupportStatus(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(status, gc.Equals, test.expected)
+	}
+}
+
+func (s *supportedSeriesSuite) TestUpdateSeriesVersionsFromReader(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	err := series.UpdateSeriesVersionsFromReader(strings.NewReader(
+		"version,codename,series,created,release,eol,eol-server,eol-esm\n" +
+			"10.04 LTS,Lucid Lynx,lucid,2009-10-29,2010-04-29,2013-05-09,2015-04-30\n" +
+			"12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26,2017-04-28,2019-04-26\n" +
+			"94.04 LTS,Ornery Omega,ornery,2094-10-21,2094-04-17,2099-04-17\n"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	version, err := series.SeriesVersion("ornery")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "94.04")
+	name, err := series.VersionSeries("94.04")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "ornery")
+
+	ubuntuSeries := series.UbuntuSupportedSeries()
+	c.Check(ubuntuSeries["ornery"], jc.DeepEquals, series.SeriesVersionInfo{
+		Version:                   "94.04 LTS",
+		LTS:                       true,
+		CreatedByDistroInfoReader: true,
+	})
+	// Known series are kept, and series prior to precise are ignored.
+	c.Check(ubuntuSeries["precise"].CreatedByDistroInfoReader, jc.IsFalse)
+	_, ok := ubuntuSeries["lucid"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *supportedSeriesSuite) TestUpdateSeriesVersionsFromReaderInvalid(c *gc.C) {
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	for i, test := range []struct {
+		contents string
+		err      string
+	}{{
+		contents: "",
+		err:      "empty distro-info not valid",
+	}, {
+		contents: "version,codename,series,created,eol\n",
+		err:      `distro-info without "release" column not valid`,
+	}, {
+		contents: "version,codename,series,created,release,eol\n" +
+			"94.04 LTS,Ornery Omega,ornery,2094-10-21,2094-04-17\n",
+		err: "distro-info line 2 with missing fields not valid",
+	}, {
+		contents: "version,codename,series,created,release,eol\n" +
+			"12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26\n" +
+			"94.04 LTS,Ornery Omega,ornery,2094-10-21,April 2094,2099-04-17\n",
+		err: `distro-info line 3: parsing time "April 2094".*`,
+	}, {
+		contents: "version,codename,series\n\"12.04,Precise\n",
+		err:      "reading distro-info: .*",
+	}} {
+		c.Logf("%d: %q", i, test.contents)
+		err := series.UpdateSeriesVersionsFromReader(strings.NewReader(test.contents))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+
+	_, err := series.SeriesVersion("ornery")
+	c.Check(err, gc.ErrorMatches, `.*"ornery".*`)
+}
+
+func (s *supportedSeriesSuite) TestSeriesEOL(c *gc.C) {
+	root := makeHostRoot(c, map[string]string{
+		series.UbuntuDistroInfo: "version,codename,series,created,release,eol,eol-server,eol-esm\n" +
+			"12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26,2017-04-28,2019-04-26\n" +
+			"16.04 LTS,Xenial Xerus,xenial,2015-10-22,2016-04-21,2021-04-21\n" +
+			"18.04 LTS,Bionic Beaver,bionic,2017-10-19,2018-04-26,2023-05-31,,2028-04-26\n",
+	})
+	h := &series.Host{Root: root}
+	for i, test := range []struct {
+		series   string
+		expected time.Time
+	}{
+		{"precise", time.Date(2017, 4, 28, 0, 0, 0, 0, time.UTC)},
+		{"xenial", time.Date(2021, 4, 21, 0, 0, 0, 0, time.UTC)},
+		{"bionic", time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)},
+	} {
+		c.Logf("%d: %s", i, test.series)
+		eol, err := h.SeriesEOL(test.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(eol.Equal(test.expected), jc.IsTrue, gc.Commentf("eol %s", eol))
+	}
+
+	_, err := h.SeriesEOL("centos7")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `end of life of series "centos7" not found`)
+}
+
+func (s *supportedSeriesSuite) TestSeriesFromVersion(c *gc.C) {
+	setSeriesTestData()
+	for _, test := range []struct {
+		version  string
+		expected string
+	}{
+		{"14.04", "trusty"},
+		{"14.04.6", "trusty"},
+		{"14.04.6 LTS", "trusty"},
+		{"14.10", "utopic"},
+	} {
+		seriesResult, err := series.SeriesFromVersion(test.version)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(seriesResult, gc.Equals, test.expected, gc.Commentf("%s", test.version))
+	}
+}
+
+func (s *supportedSeriesSuite) TestSeriesFromVersionUnknown(c *gc.C) {
+	setSeriesTestData()
+	_, err := series.SeriesFromVersion("73.04.1")
+	c.Assert(err, jc.Satisfies, series.IsUnknownVersionSeriesError)
+	c.Assert(err, gc.ErrorMatches, `version "73.04.1": unknown series for version: "73.04"`)
+}
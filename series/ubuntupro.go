@@ -0,0 +1,44 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// ubuntuProStatusFile is the name of the file that the Ubuntu Pro client
+	// writes its status to (overrideable for testing).
+	ubuntuProStatusFile = "/var/lib/ubuntu-advantage/status.json"
+)
+
+// UbuntuProAttached returns true if the host is attached to an Ubuntu Pro
+// subscription. Hosts without the Ubuntu Pro client, or where the client has
+// never written its status, are reported as not attached.
+func UbuntuProAttached() (bool, error) {
+	return defaultHost.UbuntuProAttached()
+}
+
+// UbuntuProAttached returns true if the host is attached to an Ubuntu Pro
+// subscription.
+func (h *Host) UbuntuProAttached() (bool, error) {
+	statusFile := h.path(ubuntuProStatusFile)
+	contents, err := ioutil.ReadFile(statusFile)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	var status struct {
+		Attached bool `json:"attached"`
+	}
+	if err := json.Unmarshal(contents, &status); err != nil {
+		return false, errors.Annotatef(err, "parsing %s", statusFile)
+	}
+	return status.Attached, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type ubuntuProSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&ubuntuProSuite{})
+
+func (s *ubuntuProSuite) TestUbuntuProAttached(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		contents string
+		expected bool
+	}{{
+		message:  "attached",
+		contents: `{"_doc": "Content provided in json response is currently considered Experimental and may change", "attached": true, "services": [{"name": "esm-infra", "status": "enabled"}]}`,
+		expected: true,
+	}, {
+		message:  "unattached",
+		contents: `{"attached": false, "services": []}`,
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		patchFile(c, &s.CleanupSuite, series.UbuntuProStatusFile, test.contents)
+
+		attached, err := series.UbuntuProAttached()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(attached, gc.Equals, test.expected)
+	}
+}
+
+func (s *ubuntuProSuite) TestUbuntuProAttachedNoClient(c *gc.C) {
+	s.PatchValue(series.UbuntuProStatusFile, filepath.Join(c.MkDir(), "status.json"))
+
+	attached, err := series.UbuntuProAttached()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attached, jc.IsFalse)
+}
+
+func (s *ubuntuProSuite) TestUbuntuProAttachedBadStatus(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.UbuntuProStatusFile, "not json")
+
+	_, err := series.UbuntuProAttached()
+	c.Assert(err, gc.ErrorMatches, "parsing .*status.json: .*")
+}
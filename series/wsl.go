@@ -0,0 +1,12 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+// IsWSL returns true if the host is running under the Windows Subsystem for
+// Linux. The series of a WSL host is that of its Linux distribution, such as
+// "jammy", but hardware features such as systemd, cgroups and devices may
+// not be available as they are on a machine of that series.
+func IsWSL() (bool, error) {
+	return defaultHost.IsWSL()
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// procVersionFile is the name of the file that is read in order to
+	// determine the version and build of the running kernel (overrideable
+	// for testing).
+	procVersionFile = "/proc/version"
+)
+
+// IsWSL returns true if the host is running under WSL. The kernels of both
+// WSL 1 and WSL 2 identify Microsoft in their version, such as
+// "Linux version 5.15.90.1-microsoft-standard-WSL2". The WSL_DISTRO_NAME
+// environment variable, set by WSL for processes it starts, is also
+// consulted when the host is the machine the current process is running on.
+func (h *Host) IsWSL() (bool, error) {
+	if h.Root == "" && getenv("WSL_DISTRO_NAME") != "" {
+		return true, nil
+	}
+	contents, err := ioutil.ReadFile(h.path(procVersionFile))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return strings.Contains(strings.ToLower(string(contents)), "microsoft"), nil
+}
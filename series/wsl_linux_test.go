@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/series"
+)
+
+type wslSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&wslSuite{})
+
+func (s *wslSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.PatchValue(series.Getenv, func(string) string { return "" })
+}
+
+func (s *wslSuite) TestIsWSL(c *gc.C) {
+	for i, test := range []struct {
+		version  string
+		expected bool
+	}{{
+		version:  "Linux version 5.15.90.1-microsoft-standard-WSL2 (oe-user@oe-host) (x86_64-msft-linux-gcc (GCC) 9.3.0, GNU ld (GNU Binutils) 2.34.0.20200220) #1 SMP Fri Jan 27 02:56:13 UTC 2023\n",
+		expected: true,
+	}, {
+		version:  "Linux version 4.4.0-19041-Microsoft (Microsoft@Microsoft.com) (gcc version 5.4.0 (GCC) ) #1237-Microsoft Sat Sep 11 14:32:00 PST 2021\n",
+		expected: true,
+	}, {
+		version:  "Linux version 5.15.0-91-generic (buildd@lcy02-amd64-045) (gcc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0, GNU ld (GNU Binutils for Ubuntu) 2.38) #101-Ubuntu SMP Tue Nov 14 13:30:08 UTC 2023\n",
+		expected: false,
+	}} {
+		c.Logf("%d: %s", i, test.version)
+		patchFile(c, &s.CleanupSuite, series.ProcVersionFile, test.version)
+
+		wsl, err := series.IsWSL()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(wsl, gc.Equals, test.expected)
+	}
+}
+
+func (s *wslSuite) TestIsWSLDistroName(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.ProcVersionFile, "Linux version 5.15.0-91-generic #101-Ubuntu SMP\n")
+	s.PatchValue(series.Getenv, func(name string) string {
+		if name == "WSL_DISTRO_NAME" {
+			return "Ubuntu-22.04"
+		}
+		return ""
+	})
+
+	wsl, err := series.IsWSL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(wsl, jc.IsTrue)
+
+	// The environment describes the current process, not a host with a Root.
+	s.PatchValue(series.ProcVersionFile, "/proc/version")
+	h := series.NewHost()
+	h.Root = makeHostRoot(c, map[string]string{
+		"/proc/version": "Linux version 5.15.0-91-generic #101-Ubuntu SMP\n",
+	})
+	wsl, err = h.IsWSL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(wsl, jc.IsFalse)
+}
+
+func (s *wslSuite) TestIsWSLHostSeries(c *gc.C) {
+	patchFile(c, &s.CleanupSuite, series.ProcVersionFile, "Linux version 5.15.90.1-microsoft-standard-WSL2 #1 SMP\n")
+	patchFile(c, &s.CleanupSuite, series.OSReleaseFile, "NAME=\"Ubuntu\"\nID=ubuntu\nVERSION_ID=\"22.04\"\n")
+	s.PatchValue(series.UbuntuDistroInfoPath, filepath.Join(c.MkDir(), "ubuntu.csv"))
+
+	wsl, err := series.IsWSL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(wsl, jc.IsTrue)
+
+	hostSeries, err := series.ReadSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostSeries, gc.Equals, "jammy")
+}
+
+func (s *wslSuite) TestIsWSLNoProcVersion(c *gc.C) {
+	s.PatchValue(series.ProcVersionFile, filepath.Join(c.MkDir(), "version"))
+
+	_, err := series.IsWSL()
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
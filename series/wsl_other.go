@@ -0,0 +1,12 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+// IsWSL returns false, as WSL hosts run Linux.
+func (h *Host) IsWSL() (bool, error) {
+	return false, nil
+}